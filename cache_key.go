@@ -0,0 +1,227 @@
+package ssz
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"hash"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/minio/highwayhash"
+)
+
+// fingerprintKey keys the fingerprints of values. It is random so that values whose
+// fingerprints collide cannot be crafted without knowing it, such as to poison the
+// cache with the root of another value. Fingerprints are only compared within a
+// process, so they do not need to be stable across processes.
+var fingerprintKey = func() []byte {
+	key := make([]byte, 32)
+	// Should the system fail to provide randomness, the fingerprints are still keyed
+	// by whatever was read into the key.
+	rand.Read(key)
+	return key
+}()
+
+// The fingerprint buffers small writes to amortise the cost of hashing them.
+const fingerprintBufferSize = 4096
+
+var (
+	// typeIDs numbers the types fingerprinted by the process, which is cheaper and
+	// less ambiguous than writing their names.
+	typeIDs     sync.Map
+	typeIDCount uint64
+	// fingerprintFields holds the indices of the fields fingerprinted for each
+	// struct type.
+	fingerprintFields sync.Map
+)
+
+var fingerprintPool = sync.Pool{
+	New: func() interface{} {
+		h, err := highwayhash.New(fingerprintKey)
+		if err != nil {
+			// The key is always 32 bytes long, so this cannot happen.
+			panic(err)
+		}
+		return &fingerprint{
+			hash: h,
+			buf:  make([]byte, 0, fingerprintBufferSize),
+		}
+	},
+}
+
+// fingerprint hashes a value by walking it, without marshaling it first. Its input is
+// prefix-free: the lengths of slices and the presence of pointers are written before
+// their content, and the types of interfaces before their values, so distinct values
+// of the same type always have distinct inputs.
+type fingerprint struct {
+	hash    hash.Hash
+	buf     []byte
+	scratch [8]byte
+}
+
+// cacheKey returns the key of the root of val hashed as the SSZ type typ, a fingerprint
+// of its Go type, typ, its content and maxCapacity. The SSZ type is part of the key as
+// struct tags give the same Go type different roots, such as a byte slice hashed as a
+// vector or as a list. It returns false for values of kinds which cannot be
+// fingerprinted, such as maps, whose roots are not cached.
+func cacheKey(val reflect.Value, typ reflect.Type, maxCapacity uint64) ([]byte, bool) {
+	f := fingerprintPool.Get().(*fingerprint)
+	defer fingerprintPool.Put(f)
+	f.hash.Reset()
+	f.buf = f.buf[:0]
+	f.writeUint64(typeID(val.Type()))
+	f.writeUint64(typeID(typ))
+	f.writeUint64(maxCapacity)
+	if !f.writeValue(val) {
+		return nil, false
+	}
+	f.flush()
+	return f.hash.Sum(nil), true
+}
+
+func typeID(typ reflect.Type) uint64 {
+	if id, ok := typeIDs.Load(typ); ok {
+		return id.(uint64)
+	}
+	id, _ := typeIDs.LoadOrStore(typ, atomic.AddUint64(&typeIDCount, 1))
+	return id.(uint64)
+}
+
+func (f *fingerprint) writeValue(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Bool:
+		if val.Bool() {
+			f.writeByte(1)
+		} else {
+			f.writeByte(0)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f.writeUint64(val.Uint())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f.writeUint64(uint64(val.Int()))
+	case reflect.Float32, reflect.Float64:
+		f.writeUint64(math.Float64bits(val.Float()))
+	case reflect.String:
+		f.writeUint64(uint64(val.Len()))
+		f.write([]byte(val.String()))
+	case reflect.Slice:
+		f.writeUint64(uint64(val.Len()))
+		return f.writeElements(val)
+	case reflect.Array:
+		return f.writeElements(val)
+	case reflect.Ptr:
+		if val.IsNil() {
+			f.writeByte(0)
+			return true
+		}
+		f.writeByte(1)
+		return f.writeValue(val.Elem())
+	case reflect.Interface:
+		if val.IsNil() {
+			f.writeUint64(0)
+			return true
+		}
+		f.writeUint64(typeID(val.Elem().Type()))
+		return f.writeValue(val.Elem())
+	case reflect.Struct:
+		for _, i := range fingerprintFieldsOf(val.Type()) {
+			if !f.writeValue(val.Field(i)) {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// writeElements writes the elements of a slice or array. The memory of slices and
+// addressable arrays of fixed-size numbers is written as is, which is faster than
+// walking their elements and unambiguous as the width of the elements is fixed by
+// their type.
+func (f *fingerprint) writeElements(val reflect.Value) bool {
+	n := val.Len()
+	if n == 0 {
+		return true
+	}
+	elem := val.Type().Elem()
+	if isFixedSizeNumber(elem.Kind()) && (val.Kind() == reflect.Slice || val.CanAddr()) {
+		var ptr unsafe.Pointer
+		if val.Kind() == reflect.Slice {
+			ptr = unsafe.Pointer(val.Pointer())
+		} else {
+			ptr = unsafe.Pointer(val.UnsafeAddr())
+		}
+		size := n * int(elem.Size())
+		f.write((*[1 << 30]byte)(ptr)[:size:size])
+		return true
+	}
+	for i := 0; i < n; i++ {
+		if !f.writeValue(val.Index(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isFixedSizeNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// fingerprintFieldsOf returns the indices of the fields of the struct type typ which
+// are fingerprinted. Like structFields, it skips the XXX fields of protobuf messages,
+// whose sizes are cached in them, and omitted fields, which are not part of the value.
+// Unexported fields are fingerprinted, as types hashing themselves may depend on them.
+func fingerprintFieldsOf(typ reflect.Type) []int {
+	if indices, ok := fingerprintFields.Load(typ); ok {
+		return indices.([]int)
+	}
+	indices := make([]int, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if strings.Contains(f.Name, "XXX") || isOmittedField(f) {
+			continue
+		}
+		indices = append(indices, i)
+	}
+	fingerprintFields.Store(typ, indices)
+	return indices
+}
+
+func (f *fingerprint) writeByte(b byte) {
+	if len(f.buf) == cap(f.buf) {
+		f.flush()
+	}
+	f.buf = append(f.buf, b)
+}
+
+func (f *fingerprint) writeUint64(v uint64) {
+	binary.LittleEndian.PutUint64(f.scratch[:], v)
+	f.write(f.scratch[:])
+}
+
+func (f *fingerprint) write(b []byte) {
+	if len(f.buf)+len(b) > cap(f.buf) {
+		f.flush()
+		if len(b) >= cap(f.buf) {
+			// hash.Hash never returns an error.
+			f.hash.Write(b)
+			return
+		}
+	}
+	f.buf = append(f.buf, b...)
+}
+
+func (f *fingerprint) flush() {
+	f.hash.Write(f.buf)
+	f.buf = f.buf[:0]
+}
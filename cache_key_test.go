@@ -0,0 +1,142 @@
+package ssz
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+type keyedPointers struct {
+	First  *keyedPointers
+	Second *keyedPointers
+	Value  uint64
+}
+
+type keyedUnion struct {
+	Value Union `ssz-union:"None,uint64,uint32"`
+}
+
+type keyedBigInt struct {
+	Value *big.Int `ssz-type:"uint256"`
+}
+
+func mustCacheKey(t *testing.T, val interface{}, maxCapacity uint64) []byte {
+	t.Helper()
+	key, ok := cacheKey(reflect.ValueOf(val), reflect.TypeOf(val), maxCapacity)
+	if !ok {
+		t.Fatalf("Expected a cache key for %#v", val)
+	}
+	return key
+}
+
+func TestCacheKey_DistinguishesValues(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+	}{
+		{name: "length", a: []uint64{1}, b: []uint64{1, 0}},
+		{name: "nested lengths", a: [][]byte{{1}, {}}, b: [][]byte{{}, {1}}},
+		{name: "element width", a: []uint32{1, 0}, b: []uint64{1}},
+		{name: "nil pointer", a: keyedPointers{First: &keyedPointers{}}, b: keyedPointers{Second: &keyedPointers{}}},
+		{name: "union selector", a: keyedUnion{Union{Selector: 1, Value: uint64(5)}}, b: keyedUnion{Union{Selector: 2, Value: uint64(5)}}},
+		{name: "union value type", a: Union{Selector: 1, Value: uint64(5)}, b: Union{Selector: 1, Value: uint32(5)}},
+		{name: "big integer", a: keyedBigInt{big.NewInt(1)}, b: keyedBigInt{big.NewInt(2)}},
+		{name: "bitlist", a: bitfield.Bitlist{0x05}, b: bitfield.Bitlist{0x06}},
+		{name: "array", a: [2]uint16{1, 2}, b: [2]uint16{2, 1}},
+		{name: "bool", a: []bool{true}, b: []bool{false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if bytes.Equal(mustCacheKey(t, tt.a, 0), mustCacheKey(t, tt.b, 0)) {
+				t.Errorf("Expected distinct keys for %#v and %#v", tt.a, tt.b)
+			}
+		})
+	}
+	if bytes.Equal(mustCacheKey(t, []uint64{1}, 4), mustCacheKey(t, []uint64{1}, 8)) {
+		t.Error("Expected distinct keys for distinct capacities")
+	}
+}
+
+func TestCacheKey_EqualValues(t *testing.T) {
+	a := &benchRegistry{Validators: []benchValidator{{EffectiveBalance: 1}}, Balances: []uint64{1, 2}}
+	b := &benchRegistry{Validators: []benchValidator{{EffectiveBalance: 1}}, Balances: []uint64{1, 2}}
+	if !bytes.Equal(mustCacheKey(t, a, 0), mustCacheKey(t, b, 0)) {
+		t.Error("Expected equal keys for equal values")
+	}
+	// Arrays are read in place when they are addressable, which must not change
+	// their key.
+	arr := [3]uint64{1, 2, 3}
+	addressable, ok := cacheKey(reflect.ValueOf(&arr).Elem(), reflect.TypeOf(arr), 0)
+	if !ok {
+		t.Fatal("Expected a cache key for an addressable array")
+	}
+	if !bytes.Equal(addressable, mustCacheKey(t, arr, 0)) {
+		t.Error("Expected equal keys for addressable and unaddressable arrays")
+	}
+	if _, ok := cacheKey(reflect.ValueOf(map[string]uint64{}), reflect.TypeOf(map[string]uint64{}), 0); ok {
+		t.Error("Expected no cache key for a map")
+	}
+}
+
+type keyedBytes struct {
+	Vector []byte `ssz-size:"32"`
+	List   []byte
+}
+
+func TestCache_ByteSlicesKeyedBySSZType(t *testing.T) {
+	b := bytes.Repeat([]byte{7}, 32)
+	val := keyedBytes{Vector: b, List: b}
+	want, err := HashTreeRootWithOptions(val, WithCache(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := HashTreeRootWithOptions(val, WithCache(NewCache(CacheConfig{})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Cached root = %#x, want %#x", got, want)
+	}
+}
+
+func BenchmarkCache_Hit(b *testing.B) {
+	cache := NewCache(CacheConfig{})
+	r := newBenchRegistry(16384)
+	if _, err := HashTreeRootWithOptions(r, WithCache(cache)); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := HashTreeRootWithOptions(r, WithCache(cache)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCache_Miss(b *testing.B) {
+	cache := NewCache(CacheConfig{})
+	r := newBenchRegistry(16384)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		cache.Clear()
+		if _, err := HashTreeRootWithOptions(r, WithCache(cache)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCacheKey_Balances(b *testing.B) {
+	balances := reflect.ValueOf(make([]uint64, 1<<20))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, ok := cacheKey(balances, balances.Type(), 1<<40); !ok {
+			b.Fatal("Expected a cache key")
+		}
+	}
+}
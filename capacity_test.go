@@ -0,0 +1,118 @@
+package ssz_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type cappedItem struct {
+	Values []uint64          `ssz-max:"2"`
+	Data   []byte            `ssz-max:"4"`
+	Bits   bitfield.Bitlist  `ssz-max:"8"`
+	Roots  [][]byte          `ssz-size:"?,32" ssz-max:"2"`
+	Nested []cappedContainer `ssz-max:"2"`
+}
+
+type cappedContainer struct {
+	Items []uint16 `ssz-max:"1"`
+}
+
+func validCappedItem() cappedItem {
+	return cappedItem{
+		Values: []uint64{1, 2},
+		Data:   []byte{1, 2, 3, 4},
+		Bits:   bitfield.NewBitlist(8),
+		Roots:  [][]byte{bytes.Repeat([]byte{1}, 32)},
+		Nested: []cappedContainer{{Items: []uint16{1}}},
+	}
+}
+
+func TestCapacity_WithinLimits(t *testing.T) {
+	item := validCappedItem()
+	encoded, err := ssz.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded cappedItem
+	if err := ssz.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !ssz.DeepEqual(item, decoded) {
+		t.Errorf("Expected %v, received %v", item, decoded)
+	}
+}
+
+func TestCapacity_Exceeded(t *testing.T) {
+	tests := []struct {
+		field  string
+		modify func(*cappedItem)
+	}{
+		{field: "Values", modify: func(c *cappedItem) { c.Values = []uint64{1, 2, 3} }},
+		{field: "Data", modify: func(c *cappedItem) { c.Data = []byte{1, 2, 3, 4, 5} }},
+		{field: "Bits", modify: func(c *cappedItem) { c.Bits = bitfield.NewBitlist(9) }},
+		{field: "Roots", modify: func(c *cappedItem) { c.Roots = append(c.Roots, c.Roots[0], c.Roots[0]) }},
+		{field: "Nested", modify: func(c *cappedItem) { c.Nested = append(c.Nested, c.Nested[0], c.Nested[0]) }},
+		{field: "Items", modify: func(c *cappedItem) { c.Nested[0].Items = []uint16{1, 2} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			item := validCappedItem()
+			tt.modify(&item)
+			if _, err := ssz.Marshal(item); err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Expected marshal error naming field %s, received %v", tt.field, err)
+			}
+			if err := ssz.NewEncoder(new(bytes.Buffer)).Encode(item); err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Expected encode error naming field %s, received %v", tt.field, err)
+			}
+
+			// Encode the oversized list as if it had no limit, as a peer could.
+			encoded := marshalUncapped(t, item)
+			var decoded cappedItem
+			for _, strict := range []bool{true, false} {
+				ssz.ToggleStrictUnmarshal(strict)
+				err := ssz.Unmarshal(encoded, &decoded)
+				if err == nil || !strings.Contains(err.Error(), tt.field) {
+					t.Errorf("Expected unmarshal error naming field %s with strict mode %v, received %v", tt.field, strict, err)
+				}
+			}
+			ssz.ToggleStrictUnmarshal(true)
+			err := ssz.NewDecoder(bytes.NewReader(encoded), uint64(len(encoded))).Decode(&decoded)
+			if err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Expected decode error naming field %s, received %v", tt.field, err)
+			}
+		})
+	}
+}
+
+type uncappedItem struct {
+	Values []uint64
+	Data   []byte
+	Bits   bitfield.Bitlist
+	Roots  [][]byte `ssz-size:"?,32"`
+	Nested []uncappedContainer
+}
+
+type uncappedContainer struct {
+	Items []uint16
+}
+
+func marshalUncapped(t *testing.T, item cappedItem) []byte {
+	uncapped := uncappedItem{
+		Values: item.Values,
+		Data:   item.Data,
+		Bits:   item.Bits,
+		Roots:  item.Roots,
+	}
+	for _, n := range item.Nested {
+		uncapped.Nested = append(uncapped.Nested, uncappedContainer{Items: n.Items})
+	}
+	encoded, err := ssz.Marshal(uncapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
@@ -0,0 +1,480 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+const (
+	bytesPerLengthOffset = 4
+	bytesPerChunk        = 32
+)
+
+// generator accumulates the source of the generated methods.
+type generator struct {
+	buf bytes.Buffer
+}
+
+func (g *generator) p(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+	g.buf.WriteByte('\n')
+}
+
+// generate emits the methods of the requested struct types, or of every struct
+// type in the package when names is empty, and returns the gofmt-ed source.
+func generate(pkg *pkgInfo, names []string, source string) ([]byte, error) {
+	if len(names) == 0 {
+		names = pkg.order
+	}
+	var objs []*object
+	for _, name := range names {
+		obj, err := pkg.object(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+
+	body := &generator{}
+	for _, obj := range objs {
+		body.object(obj)
+	}
+
+	out := &generator{}
+	out.p("// Code generated by sszgen. DO NOT EDIT.")
+	out.p("// source: %s", source)
+	out.p("")
+	out.p("package %s", pkg.name)
+	out.p("")
+	out.p("import (")
+	if strings.Contains(body.buf.String(), "bitfield.") {
+		out.p("\"github.com/prysmaticlabs/go-bitfield\"")
+	}
+	out.p("ssz \"github.com/prysmaticlabs/go-ssz\"")
+	out.p(")")
+	out.buf.Write(body.buf.Bytes())
+	code, err := format.Source(out.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format generated code: %v", err)
+	}
+	return code, nil
+}
+
+func (g *generator) object(obj *object) {
+	recv := strings.ToLower(obj.name[:1])
+	g.p("")
+	g.p("// MarshalSSZ ssz marshals the %s object.", obj.name)
+	g.p("func (%s *%s) MarshalSSZ() ([]byte, error) {", recv, obj.name)
+	g.p("return %s.MarshalSSZTo(make([]byte, 0, %s.SizeSSZ()))", recv, recv)
+	g.p("}")
+
+	g.p("")
+	g.p("// MarshalSSZTo ssz marshals the %s object and appends it to buf.", obj.name)
+	g.p("func (%s *%s) MarshalSSZTo(buf []byte) (dst []byte, err error) {", recv, obj.name)
+	g.p("if %s == nil {", recv)
+	g.p("%s = new(%s)", recv, obj.name)
+	g.p("}")
+	g.p("dst = buf")
+	if !obj.isFixed() {
+		g.p("offset := %d", obj.fixedSize())
+	}
+	for i, f := range obj.fields {
+		v := recv + "." + f.name
+		g.p("")
+		g.p("// Field (%d) '%s'", i, f.name)
+		if f.typ.isFixed() {
+			g.marshal(f.typ, v, "dst", "nil", 0)
+			continue
+		}
+		g.p("dst = ssz.WriteOffset(dst, offset)")
+		g.size(f.typ, v, "offset", 0)
+	}
+	for i, f := range obj.fields {
+		if f.typ.isFixed() {
+			continue
+		}
+		g.p("")
+		g.p("// Field (%d) '%s'", i, f.name)
+		g.marshal(f.typ, recv+"."+f.name, "dst", "nil", 0)
+	}
+	g.p("return dst, nil")
+	g.p("}")
+
+	g.p("")
+	g.p("// SizeSSZ returns the ssz encoded size in bytes of the %s object.", obj.name)
+	g.p("func (%s *%s) SizeSSZ() (size int) {", recv, obj.name)
+	if !obj.isFixed() {
+		g.p("if %s == nil {", recv)
+		g.p("%s = new(%s)", recv, obj.name)
+		g.p("}")
+	}
+	g.p("size = %d", obj.fixedSize())
+	for i, f := range obj.fields {
+		if f.typ.isFixed() {
+			continue
+		}
+		g.p("")
+		g.p("// Field (%d) '%s'", i, f.name)
+		g.size(f.typ, recv+"."+f.name, "size", 0)
+	}
+	g.p("return size")
+	g.p("}")
+
+	g.unmarshalObject(obj, recv)
+	g.hashObject(obj, recv)
+}
+
+// marshal appends the encoding of v to the byte slice dst. Errors return fail
+// alongside the error.
+func (g *generator) marshal(t *sszType, v string, dst string, fail string, depth int) {
+	switch t.kind {
+	case kindBool:
+		g.p("%s = ssz.MarshalBool(%s, %s)", dst, dst, v)
+	case kindUint:
+		if t.size == 1 {
+			g.p("%s = append(%s, %s)", dst, dst, v)
+		} else {
+			g.p("%s = ssz.MarshalUint%d(%s, %s)", dst, t.size*8, dst, v)
+		}
+	case kindBytes:
+		if t.isArray {
+			g.p("%s = append(%s, %s[:]...)", dst, dst, v)
+			return
+		}
+		g.p("if %s, err = ssz.MarshalFixedBytes(%s, %s, %d); err != nil {", dst, dst, v, t.size)
+		g.p("return %s, err", fail)
+		g.p("}")
+	case kindByteList:
+		g.p("if uint64(len(%s)) > %d {", v, t.max)
+		g.p("return %s, ssz.ErrListTooBig", fail)
+		g.p("}")
+		g.p("%s = append(%s, %s...)", dst, dst, v)
+	case kindBitlist:
+		g.p("if %s.Len() > %d {", v, t.max)
+		g.p("return %s, ssz.ErrListTooBig", fail)
+		g.p("}")
+		g.p("%s = append(%s, %s...)", dst, dst, v)
+	case kindVector, kindList:
+		if t.kind == kindList {
+			g.p("if uint64(len(%s)) > %d {", v, t.max)
+			g.p("return %s, ssz.ErrListTooBig", fail)
+			g.p("}")
+		} else if !t.isArray {
+			g.p("if len(%s) != %d {", v, t.size)
+			g.p("return %s, ssz.ErrVectorLength", fail)
+			g.p("}")
+		}
+		idx := fmt.Sprintf("i%d", depth)
+		elem := fmt.Sprintf("%s[%s]", v, idx)
+		if t.elem.isFixed() {
+			g.p("for %s := range %s {", idx, v)
+			g.marshal(t.elem, elem, dst, fail, depth+1)
+			g.p("}")
+			return
+		}
+		offset := fmt.Sprintf("offset%d", depth)
+		g.p("{")
+		g.p("%s := %d * len(%s)", offset, bytesPerLengthOffset, v)
+		g.p("for %s := range %s {", idx, v)
+		g.p("%s = ssz.WriteOffset(%s, %s)", dst, dst, offset)
+		g.size(t.elem, elem, offset, depth+1)
+		g.p("}")
+		g.p("for %s := range %s {", idx, v)
+		g.marshal(t.elem, elem, dst, fail, depth+1)
+		g.p("}")
+		g.p("}")
+	case kindContainer:
+		g.p("if %s, err = %s.MarshalSSZTo(%s); err != nil {", dst, v, dst)
+		g.p("return %s, err", fail)
+		g.p("}")
+	}
+}
+
+// size adds the encoded size of v to the int variable acc.
+func (g *generator) size(t *sszType, v string, acc string, depth int) {
+	if t.isFixed() {
+		g.p("%s += %d", acc, t.fixedSize())
+		return
+	}
+	switch t.kind {
+	case kindByteList, kindBitlist:
+		g.p("%s += len(%s)", acc, v)
+	case kindVector, kindList:
+		if t.elem.isFixed() {
+			g.p("%s += len(%s) * %d", acc, v, t.elem.fixedSize())
+			return
+		}
+		idx := fmt.Sprintf("i%d", depth)
+		g.p("for %s := range %s {", idx, v)
+		g.p("%s += %d", acc, bytesPerLengthOffset)
+		g.size(t.elem, fmt.Sprintf("%s[%s]", v, idx), acc, depth+1)
+		g.p("}")
+	case kindContainer:
+		g.p("%s += %s.SizeSSZ()", acc, v)
+	}
+}
+
+func (g *generator) unmarshalObject(obj *object, recv string) {
+	fixedSize := obj.fixedSize()
+	g.p("")
+	g.p("// UnmarshalSSZ ssz unmarshals the %s object.", obj.name)
+	g.p("func (%s *%s) UnmarshalSSZ(buf []byte) error {", recv, obj.name)
+	g.p("var err error")
+	g.p("size := uint64(len(buf))")
+	if obj.isFixed() {
+		g.p("if size != %d {", fixedSize)
+	} else {
+		g.p("if size < %d {", fixedSize)
+	}
+	g.p("return ssz.ErrIncorrectSize")
+	g.p("}")
+
+	var offsets []int
+	var index uint64
+	for i, f := range obj.fields {
+		g.p("")
+		g.p("// Field (%d) '%s'", i, f.name)
+		end := index + f.typ.fixedSize()
+		if f.typ.isFixed() {
+			src := fmt.Sprintf("buf[%d:%d]", index, end)
+			if f.typ.kind == kindVector {
+				// Vectors index into their own encoding, which we slice out first.
+				g.p("{")
+				g.p("buf := %s", src)
+				g.unmarshal(f.typ, recv+"."+f.name, "buf", 0)
+				g.p("}")
+			} else {
+				g.unmarshal(f.typ, recv+"."+f.name, src, 0)
+			}
+			index = end
+			continue
+		}
+		name := fmt.Sprintf("o%d", i)
+		g.p("%s := ssz.ReadOffset(buf[%d:%d])", name, index, end)
+		if len(offsets) == 0 {
+			g.p("if %s != %d {", name, fixedSize)
+		} else {
+			g.p("if %s < o%d || %s > size {", name, offsets[len(offsets)-1], name)
+		}
+		g.p("return ssz.ErrInvalidOffset")
+		g.p("}")
+		offsets = append(offsets, i)
+		index = end
+	}
+	for j, i := range offsets {
+		f := obj.fields[i]
+		g.p("")
+		g.p("// Field (%d) '%s'", i, f.name)
+		g.p("{")
+		if j+1 < len(offsets) {
+			g.p("buf := buf[o%d:o%d]", i, offsets[j+1])
+		} else {
+			g.p("buf := buf[o%d:]", i)
+		}
+		g.unmarshal(f.typ, recv+"."+f.name, "buf", 0)
+		g.p("}")
+	}
+	g.p("return err")
+	g.p("}")
+}
+
+// unmarshal decodes v from src, an expression evaluating to exactly the
+// encoding of v.
+func (g *generator) unmarshal(t *sszType, v string, src string, depth int) {
+	switch t.kind {
+	case kindBool:
+		g.p("if %s, err = ssz.UnmarshalBool(%s); err != nil {", v, src)
+		g.p("return err")
+		g.p("}")
+	case kindUint:
+		if t.size == 1 {
+			g.p("%s = %s[0]", v, src)
+		} else {
+			g.p("%s = ssz.UnmarshalUint%d(%s)", v, t.size*8, src)
+		}
+	case kindBytes:
+		if t.isArray {
+			g.p("copy(%s[:], %s)", v, src)
+		} else {
+			g.p("%s = append(%s[:0], %s...)", v, v, src)
+		}
+	case kindByteList:
+		g.p("if uint64(len(%s)) > %d {", src, t.max)
+		g.p("return ssz.ErrListTooBig")
+		g.p("}")
+		g.p("%s = append(%s[:0], %s...)", v, v, src)
+	case kindBitlist:
+		g.p("if err = ssz.ValidateBitlist(%s, %d); err != nil {", src, t.max)
+		g.p("return err")
+		g.p("}")
+		g.p("%s = append(%s[:0], %s...)", v, v, src)
+	case kindVector, kindList:
+		idx := fmt.Sprintf("i%d", depth)
+		elem := fmt.Sprintf("%s[%s]", v, idx)
+		if t.elem.isFixed() {
+			elemSize := t.elem.fixedSize()
+			num := fmt.Sprintf("%d", t.size)
+			if t.kind == kindList {
+				num = fmt.Sprintf("num%d", depth)
+				g.p("if uint64(len(%s))%%%d != 0 {", src, elemSize)
+				g.p("return ssz.ErrIncorrectSize")
+				g.p("}")
+				g.p("%s := len(%s) / %d", num, src, elemSize)
+				g.p("if uint64(%s) > %d {", num, t.max)
+				g.p("return ssz.ErrListTooBig")
+				g.p("}")
+			}
+			if !t.isArray {
+				g.p("%s = make(%s, %s)", v, t.expr, num)
+			}
+			g.p("for %s := 0; %s < %s; %s++ {", idx, idx, num, idx)
+			g.newContainer(t.elem, elem)
+			g.unmarshal(t.elem, elem, fmt.Sprintf("%s[%s*%d : (%s+1)*%d]", src, idx, elemSize, idx, elemSize), depth+1)
+			g.p("}")
+			return
+		}
+		items := fmt.Sprintf("items%d", depth)
+		limit := t.max
+		if t.kind == kindVector {
+			limit = t.size
+		}
+		g.p("%s, err := ssz.SplitOffsets(%s, %d)", items, src, limit)
+		g.p("if err != nil {")
+		g.p("return err")
+		g.p("}")
+		if t.kind == kindVector {
+			g.p("if len(%s) != %d {", items, t.size)
+			g.p("return ssz.ErrVectorLength")
+			g.p("}")
+		}
+		if !t.isArray {
+			g.p("%s = make(%s, len(%s))", v, t.expr, items)
+		}
+		g.p("for %s := range %s {", idx, items)
+		g.newContainer(t.elem, elem)
+		g.unmarshal(t.elem, elem, fmt.Sprintf("%s[%s]", items, idx), depth+1)
+		g.p("}")
+	case kindContainer:
+		g.newContainer(t, v)
+		g.p("if err = %s.UnmarshalSSZ(%s); err != nil {", v, src)
+		g.p("return err")
+		g.p("}")
+	}
+}
+
+// newContainer allocates pointer containers before they are decoded into.
+func (g *generator) newContainer(t *sszType, v string) {
+	if t.kind != kindContainer || !t.ptr {
+		return
+	}
+	g.p("if %s == nil {", v)
+	g.p("%s = new(%s)", v, t.obj.name)
+	g.p("}")
+}
+
+func (g *generator) hashObject(obj *object, recv string) {
+	g.p("")
+	g.p("// HashTreeRoot ssz hashes the %s object.", obj.name)
+	g.p("func (%s *%s) HashTreeRoot() ([32]byte, error) {", recv, obj.name)
+	g.p("if %s == nil {", recv)
+	g.p("%s = new(%s)", recv, obj.name)
+	g.p("}")
+	if len(obj.fields) > 0 {
+		g.p("var err error")
+	}
+	g.p("roots := make([][]byte, 0, %d)", len(obj.fields))
+	for i, f := range obj.fields {
+		root := fmt.Sprintf("root%d", i)
+		g.p("")
+		g.p("// Field (%d) '%s'", i, f.name)
+		g.p("var %s [32]byte", root)
+		g.hash(f.typ, recv+"."+f.name, root, 0)
+		g.p("roots = append(roots, %s[:])", root)
+	}
+	g.p("return ssz.Merkleize(roots, %d)", len(obj.fields))
+	g.p("}")
+}
+
+// hash computes the tree hash root of v into the [32]byte variable out.
+func (g *generator) hash(t *sszType, v string, out string, depth int) {
+	switch {
+	case t.kind == kindBitlist:
+		g.p("if %s, err = ssz.BitlistRoot(%s, %d); err != nil {", out, v, t.max)
+		g.p("return [32]byte{}, err")
+		g.p("}")
+	case t.isPacked():
+		buf := fmt.Sprintf("buf%d", depth)
+		g.p("{")
+		g.p("var %s []byte", buf)
+		g.marshal(t, v, buf, "[32]byte{}", depth)
+		g.p("if %s, err = ssz.Merkleize(ssz.Pack(%s), %d); err != nil {", out, buf, packedLimit(t))
+		g.p("return [32]byte{}, err")
+		g.p("}")
+		g.mixInLength(t, v, out)
+		g.p("}")
+	case t.kind == kindVector || t.kind == kindList:
+		if t.kind == kindList {
+			g.p("if uint64(len(%s)) > %d {", v, t.max)
+			g.p("return [32]byte{}, ssz.ErrListTooBig")
+			g.p("}")
+		} else if !t.isArray {
+			g.p("if len(%s) != %d {", v, t.size)
+			g.p("return [32]byte{}, ssz.ErrVectorLength")
+			g.p("}")
+		}
+		idx := fmt.Sprintf("i%d", depth)
+		roots := fmt.Sprintf("roots%d", depth)
+		elemRoot := fmt.Sprintf("elemRoot%d", depth)
+		limit := t.max
+		if t.kind == kindVector {
+			limit = t.size
+		}
+		g.p("{")
+		g.p("%s := make([][]byte, 0, len(%s))", roots, v)
+		g.p("for %s := range %s {", idx, v)
+		g.p("var %s [32]byte", elemRoot)
+		g.hash(t.elem, fmt.Sprintf("%s[%s]", v, idx), elemRoot, depth+1)
+		g.p("%s = append(%s, %s[:])", roots, roots, elemRoot)
+		g.p("}")
+		g.p("if %s, err = ssz.Merkleize(%s, %d); err != nil {", out, roots, limit)
+		g.p("return [32]byte{}, err")
+		g.p("}")
+		g.mixInLength(t, v, out)
+		g.p("}")
+	case t.kind == kindContainer:
+		g.p("if %s, err = %s.HashTreeRoot(); err != nil {", out, v)
+		g.p("return [32]byte{}, err")
+		g.p("}")
+	}
+}
+
+func (g *generator) mixInLength(t *sszType, v string, out string) {
+	if t.kind == kindList || t.kind == kindByteList {
+		g.p("%s = ssz.MixInLength(%s, uint64(len(%s)))", out, out, v)
+	}
+}
+
+// packedLimit returns the number of chunks a packed type is padded to, which
+// for lists is derived from their ssz-max capacity.
+func packedLimit(t *sszType) uint64 {
+	var limit uint64
+	switch t.kind {
+	case kindBool, kindUint:
+		return 1
+	case kindBytes:
+		limit = (t.size + bytesPerChunk - 1) / bytesPerChunk
+	case kindByteList:
+		limit = (t.max + bytesPerChunk - 1) / bytesPerChunk
+	case kindVector:
+		limit = (t.size*t.elem.fixedSize() + bytesPerChunk - 1) / bytesPerChunk
+	case kindList:
+		limit = (t.max*t.elem.fixedSize() + bytesPerChunk - 1) / bytesPerChunk
+	}
+	// The reflective hasher pads empty lists to a single chunk.
+	if limit == 0 {
+		limit = 1
+	}
+	return limit
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func parseSource(t *testing.T, src string) *pkgInfo {
+	dir, err := ioutil.TempDir("", "sszgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fPath := filepath.Join(dir, "types.go")
+	if err := ioutil.WriteFile(fPath, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := parseFiles([]string{fPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestGenerate_Layout(t *testing.T) {
+	pkg := parseSource(t, `package example
+
+type checkpoint struct {
+	Epoch uint64
+	Root  []byte `+"`ssz-size:\"32\"`"+`
+}
+
+type votes struct {
+	Checkpoints []*checkpoint `+"`ssz-max:\"16\"`"+`
+	Roots       [][]byte      `+"`ssz-size:\"?,32\" ssz-max:\"8\"`"+`
+	Slashings   []uint64      `+"`ssz-size:\"4\"`"+`
+	Flag        bool
+}
+`)
+	obj, err := pkg.object("votes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.isFixed() {
+		t.Error("Expected struct with lists to be variable-size")
+	}
+	// Two offsets, a four item uint64 vector and a bool.
+	if size := obj.fixedSize(); size != 4+4+32+1 {
+		t.Errorf("Expected fixed size of 41, received %d", size)
+	}
+	wantKinds := []kind{kindList, kindList, kindVector, kindBool}
+	for i, f := range obj.fields {
+		if f.typ.kind != wantKinds[i] {
+			t.Errorf("Field %s: expected kind %d, received %d", f.name, wantKinds[i], f.typ.kind)
+		}
+	}
+	if elem := obj.fields[1].typ.elem; elem.kind != kindBytes || elem.size != 32 {
+		t.Errorf("Expected [][]byte with ssz-size ?,32 to hold 32 byte vectors, received %+v", elem)
+	}
+	code, err := generate(pkg, nil, "types.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range []string{"MarshalSSZ", "MarshalSSZTo", "SizeSSZ", "UnmarshalSSZ", "HashTreeRoot"} {
+		if !strings.Contains(string(code), ") "+method+"(") {
+			t.Errorf("Expected generated code to contain method %s", method)
+		}
+	}
+}
+
+func TestGenerate_ListRequiresCapacity(t *testing.T) {
+	pkg := parseSource(t, `package example
+
+type balances struct {
+	Balances []uint64
+}
+`)
+	if _, err := generate(pkg, nil, "types.go"); err == nil {
+		t.Error("Expected list without ssz-max tag to fail")
+	}
+}
+
+func TestGenerate_SkipsRootCacheAndOmittedFields(t *testing.T) {
+	pkg := parseSource(t, `package example
+
+import "github.com/prysmaticlabs/go-ssz"
+
+type state struct {
+	ssz.RootCache
+	Slot  uint64
+	Cache []byte `+"`ssz:\"-\"`"+`
+}
+`)
+	obj, err := pkg.object("state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(obj.fields) != 1 || obj.fields[0].name != "Slot" {
+		t.Errorf("Expected the RootCache and omitted fields to be skipped, received fields %+v", obj.fields)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Command sszgen generates reflection-free SSZ methods for Go struct types.
+
+For every struct type found in the input it emits MarshalSSZ, MarshalSSZTo,
+SizeSSZ, UnmarshalSSZ and HashTreeRoot methods which produce exactly the same
+output as ssz.Marshal, ssz.Unmarshal and ssz.HashTreeRoot, honouring the
+ssz-size and ssz-max struct tags in the same way as the reflective engine.
+
+Usage:
+
+  sszgen -path ./types.go [-objs Block,State] [-output ./types_encoding.go]
+
+When path is a directory, every non-test Go file in it is parsed and the methods
+are written to ssz_encoding.go in that directory. Lists must declare their
+capacity with an ssz-max tag.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		source = flag.String("path", "", "Go source file or package directory to parse")
+		objs   = flag.String("objs", "", "comma-separated list of types to generate (default: all struct types)")
+		output = flag.String("output", "", "output file (default: <file>_encoding.go or ssz_encoding.go)")
+	)
+	flag.Parse()
+	if *source == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*source, *objs, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "sszgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(source string, objs string, output string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	var files []string
+	if info.IsDir() {
+		matches, err := filepath.Glob(filepath.Join(source, "*.go"))
+		if err != nil {
+			return err
+		}
+		for _, m := range matches {
+			if strings.HasSuffix(m, "_test.go") || strings.HasSuffix(m, "_encoding.go") {
+				continue
+			}
+			files = append(files, m)
+		}
+		if output == "" {
+			output = filepath.Join(source, "ssz_encoding.go")
+		}
+	} else {
+		files = []string{source}
+		if output == "" {
+			output = strings.TrimSuffix(source, ".go") + "_encoding.go"
+		}
+	}
+	var names []string
+	if objs != "" {
+		names = strings.Split(objs, ",")
+	}
+	pkg, err := parseFiles(files)
+	if err != nil {
+		return err
+	}
+	code, err := generate(pkg, names, filepath.Base(source))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(output, code, 0644)
+}
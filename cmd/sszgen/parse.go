@@ -0,0 +1,372 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// kind classifies a field type the same way the reflective engine
+// distinguishes its marshalers, unmarshalers and hashers.
+type kind int
+
+const (
+	kindBool kind = iota
+	kindUint
+	kindBytes
+	kindByteList
+	kindBitlist
+	kindVector
+	kindList
+	kindContainer
+)
+
+// bitvectorSizes maps the go-bitfield bitvector types to their encoded byte size.
+var bitvectorSizes = map[string]uint64{
+	"Bitvector4":   1,
+	"Bitvector8":   1,
+	"Bitvector32":  4,
+	"Bitvector64":  8,
+	"Bitvector128": 16,
+	"Bitvector256": 32,
+	"Bitvector512": 64,
+}
+
+// sszType describes the SSZ shape of a Go type expression.
+type sszType struct {
+	kind kind
+	// expr is the Go type expression, used when allocating values.
+	expr string
+	// size is the byte width of uints and the length of vectors.
+	size uint64
+	// max is the ssz-max capacity of lists and bitlists.
+	max uint64
+	// isArray reports whether a vector is backed by a Go array rather than a slice.
+	isArray bool
+	elem    *sszType
+	// obj is the struct definition of a container, ptr whether it is referenced by pointer.
+	obj *object
+	ptr bool
+}
+
+// object is a struct type for which methods are generated.
+type object struct {
+	name   string
+	fields []*objectField
+}
+
+type objectField struct {
+	name string
+	typ  *sszType
+}
+
+type pkgInfo struct {
+	name    string
+	order   []string
+	structs map[string]*ast.StructType
+	objects map[string]*object
+}
+
+func parseFiles(files []string) (*pkgInfo, error) {
+	fset := token.NewFileSet()
+	pkg := &pkgInfo{
+		structs: make(map[string]*ast.StructType),
+		objects: make(map[string]*object),
+	}
+	for _, f := range files {
+		file, err := parser.ParseFile(fset, f, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.name != "" && pkg.name != file.Name.Name {
+			return nil, fmt.Errorf("found packages %s and %s", pkg.name, file.Name.Name)
+		}
+		pkg.name = file.Name.Name
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				pkg.structs[ts.Name.Name] = st
+				pkg.order = append(pkg.order, ts.Name.Name)
+			}
+		}
+	}
+	return pkg, nil
+}
+
+// object resolves the SSZ layout of the named struct type, resolving the
+// struct types it references along the way.
+func (p *pkgInfo) object(name string) (*object, error) {
+	if obj, ok := p.objects[name]; ok {
+		if obj == nil {
+			return nil, fmt.Errorf("type %s refers to itself", name)
+		}
+		return obj, nil
+	}
+	st, ok := p.structs[name]
+	if !ok {
+		return nil, fmt.Errorf("struct type %s not found", name)
+	}
+	// Mark the type as in progress to detect recursive definitions.
+	p.objects[name] = nil
+	obj := &object{name: name}
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 && isRootCache(f.Type) {
+			// An embedded ssz.RootCache is not part of the encoding.
+			continue
+		}
+		if len(f.Names) == 0 {
+			delete(p.objects, name)
+			return nil, fmt.Errorf("%s: embedded fields are not supported", name)
+		}
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			unquoted, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				delete(p.objects, name)
+				return nil, err
+			}
+			tag = reflect.StructTag(unquoted)
+		}
+		if isOmitted(tag) {
+			continue
+		}
+		for _, n := range f.Names {
+			// Protobuf bookkeeping fields are skipped, as in structFields.
+			if strings.Contains(n.Name, "XXX") || !n.IsExported() {
+				continue
+			}
+			typ, err := p.fieldType(f.Type, tag)
+			if err != nil {
+				delete(p.objects, name)
+				return nil, fmt.Errorf("%s.%s: %v", name, n.Name, err)
+			}
+			obj.fields = append(obj.fields, &objectField{name: n.Name, typ: typ})
+		}
+	}
+	p.objects[name] = obj
+	return obj, nil
+}
+
+func (p *pkgInfo) fieldType(expr ast.Expr, tag reflect.StructTag) (*sszType, error) {
+	var sizes []string
+	if s, ok := tag.Lookup("ssz-size"); ok {
+		sizes = strings.Split(s, ",")
+	}
+	var max uint64
+	maxTag, hasMax := tag.Lookup("ssz-max")
+	if hasMax {
+		var err error
+		if max, err = strconv.ParseUint(maxTag, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid ssz-max tag %q: %v", maxTag, err)
+		}
+	}
+	return p.resolve(expr, sizes, max, hasMax)
+}
+
+// resolve maps a type expression to its SSZ shape. Every slice level consumes
+// one ssz-size dimension; a "?" dimension or a missing ssz-size tag makes the
+// slice a list, which must be bounded by the ssz-max tag of the field.
+func (p *pkgInfo) resolve(expr ast.Expr, sizes []string, max uint64, hasMax bool) (*sszType, error) {
+	exprString := types.ExprString(expr)
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "bool":
+			return &sszType{kind: kindBool, expr: exprString, size: 1}, nil
+		case "uint8", "byte":
+			return &sszType{kind: kindUint, expr: exprString, size: 1}, nil
+		case "uint16":
+			return &sszType{kind: kindUint, expr: exprString, size: 2}, nil
+		case "uint32":
+			return &sszType{kind: kindUint, expr: exprString, size: 4}, nil
+		case "uint64":
+			return &sszType{kind: kindUint, expr: exprString, size: 8}, nil
+		}
+		obj, err := p.object(e.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &sszType{kind: kindContainer, expr: exprString, obj: obj}, nil
+	case *ast.StarExpr:
+		ident, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported pointer type %s", exprString)
+		}
+		obj, err := p.object(ident.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &sszType{kind: kindContainer, expr: exprString, obj: obj, ptr: true}, nil
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); !ok || pkg.Name != "bitfield" {
+			return nil, fmt.Errorf("unsupported type %s", exprString)
+		}
+		if e.Sel.Name == "Bitlist" {
+			if !hasMax {
+				return nil, fmt.Errorf("bitlist requires an ssz-max tag")
+			}
+			return &sszType{kind: kindBitlist, expr: exprString, max: max}, nil
+		}
+		size, ok := bitvectorSizes[e.Sel.Name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %s", exprString)
+		}
+		if len(sizes) > 0 {
+			n, err := strconv.ParseUint(sizes[0], 10, 64)
+			if err != nil || n != size {
+				return nil, fmt.Errorf("ssz-size %s does not match %s", sizes[0], exprString)
+			}
+		}
+		return &sszType{kind: kindBytes, expr: exprString, size: size}, nil
+	case *ast.ArrayType:
+		if e.Len != nil {
+			return p.resolveArray(e, exprString)
+		}
+		var dim string
+		if len(sizes) > 0 {
+			dim, sizes = sizes[0], sizes[1:]
+		}
+		isBytes := isByteIdent(e.Elt)
+		if dim == "" || dim == "?" {
+			if !hasMax {
+				return nil, fmt.Errorf("list %s requires an ssz-max tag", exprString)
+			}
+			if isBytes {
+				return &sszType{kind: kindByteList, expr: exprString, max: max}, nil
+			}
+			// Only the outermost list is bounded by ssz-max.
+			elem, err := p.resolve(e.Elt, sizes, 0, false)
+			if err != nil {
+				return nil, err
+			}
+			return &sszType{kind: kindList, expr: exprString, max: max, elem: elem}, nil
+		}
+		n, err := strconv.ParseUint(dim, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ssz-size dimension %q", dim)
+		}
+		if isBytes {
+			return &sszType{kind: kindBytes, expr: exprString, size: n}, nil
+		}
+		elem, err := p.resolve(e.Elt, sizes, max, hasMax)
+		if err != nil {
+			return nil, err
+		}
+		return &sszType{kind: kindVector, expr: exprString, size: n, elem: elem}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", exprString)
+}
+
+func (p *pkgInfo) resolveArray(e *ast.ArrayType, exprString string) (*sszType, error) {
+	lit, ok := e.Len.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return nil, fmt.Errorf("array length of %s must be an integer literal", exprString)
+	}
+	n, err := strconv.ParseUint(lit.Value, 0, 64)
+	if err != nil {
+		return nil, err
+	}
+	if isByteIdent(e.Elt) {
+		return &sszType{kind: kindBytes, expr: exprString, size: n, isArray: true}, nil
+	}
+	elem, err := p.resolve(e.Elt, nil, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	return &sszType{kind: kindVector, expr: exprString, size: n, elem: elem, isArray: true}, nil
+}
+
+func isByteIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && (ident.Name == "byte" || ident.Name == "uint8")
+}
+
+// isFixed reports whether the encoded size of the type is known up front.
+func (t *sszType) isFixed() bool {
+	switch t.kind {
+	case kindByteList, kindBitlist, kindList:
+		return false
+	case kindVector:
+		return t.elem.isFixed()
+	case kindContainer:
+		return t.obj.isFixed()
+	}
+	return true
+}
+
+// fixedSize returns the number of bytes the type occupies in the fixed part
+// of its parent, which is BytesPerLengthOffset for variable-size types.
+func (t *sszType) fixedSize() uint64 {
+	if !t.isFixed() {
+		return bytesPerLengthOffset
+	}
+	switch t.kind {
+	case kindBool:
+		return 1
+	case kindUint, kindBytes:
+		return t.size
+	case kindVector:
+		return t.size * t.elem.fixedSize()
+	case kindContainer:
+		return t.obj.fixedSize()
+	}
+	return 0
+}
+
+// isPacked reports whether the type is hashed by packing its serialization
+// into chunks, as opposed to merkleizing the roots of its elements.
+func (t *sszType) isPacked() bool {
+	switch t.kind {
+	case kindBool, kindUint, kindBytes, kindByteList:
+		return true
+	case kindVector, kindList:
+		return t.elem.kind == kindBool || t.elem.kind == kindUint
+	}
+	return false
+}
+
+func (o *object) isFixed() bool {
+	for _, f := range o.fields {
+		if !f.typ.isFixed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *object) fixedSize() uint64 {
+	size := uint64(0)
+	for _, f := range o.fields {
+		size += f.typ.fixedSize()
+	}
+	return size
+}
+
+// isRootCache reports whether expr refers to the RootCache type of go-ssz.
+func isRootCache(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "RootCache"
+}
+
+// isOmitted reports whether tag excludes its field from the encoding with `ssz:"-"`.
+func isOmitted(tag reflect.StructTag) bool {
+	for _, option := range strings.Split(tag.Get("ssz"), ",") {
+		if strings.TrimSpace(option) == "-" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package ssz
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// The helpers in this file are exported for use by the reflection-free methods
+// generated by cmd/sszgen. They mirror the internal building blocks of the
+// reflective marshaler, unmarshaler and tree hasher so both paths produce
+// identical output.
+
+var (
+	// ErrIncorrectSize is returned when an encoding does not have the byte size of its type.
+	ErrIncorrectSize = errors.New("incorrect byte size")
+	// ErrInvalidOffset is returned when a variable-size offset points outside of its input.
+	ErrInvalidOffset = errors.New("invalid offset")
+	// ErrListTooBig is returned when a list holds more items than its ssz-max capacity.
+	ErrListTooBig = errors.New("list exceeds its maximum length")
+	// ErrVectorLength is returned when a fixed-size field does not hold exactly its ssz-size items.
+	ErrVectorLength = errors.New("incorrect vector length")
+	// ErrInvalidBool is returned when a boolean is encoded as anything other than 0 or 1.
+	ErrInvalidBool = errors.New("expected 0 or 1 for a boolean")
+	// ErrInvalidBitlist is returned when a bitlist encoding is missing its length bit.
+	ErrInvalidBitlist = errors.New("bitlist is missing its length bit")
+)
+
+// MarshalBool appends the SSZ encoding of a bool to dst.
+func MarshalBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, 1)
+	}
+	return append(dst, 0)
+}
+
+// MarshalUint16 appends the little-endian encoding of a uint16 to dst.
+func MarshalUint16(dst []byte, v uint16) []byte {
+	var buf [2]byte
+	binary.LittleEndian.PutUint16(buf[:], v)
+	return append(dst, buf[:]...)
+}
+
+// MarshalUint32 appends the little-endian encoding of a uint32 to dst.
+func MarshalUint32(dst []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(dst, buf[:]...)
+}
+
+// MarshalUint64 appends the little-endian encoding of a uint64 to dst.
+func MarshalUint64(dst []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(dst, buf[:]...)
+}
+
+// MarshalFixedBytes appends b to dst as a byte vector of the given size. Shorter
+// inputs, such as unset signatures, are right-padded with zero bytes just like the
+// reflective marshaler does, while longer inputs are rejected.
+func MarshalFixedBytes(dst []byte, b []byte, size int) ([]byte, error) {
+	if len(b) > size {
+		return nil, ErrVectorLength
+	}
+	dst = append(dst, b...)
+	return append(dst, make([]byte, size-len(b))...), nil
+}
+
+// WriteOffset appends a BytesPerLengthOffset-byte offset to dst.
+func WriteOffset(dst []byte, offset int) []byte {
+	return MarshalUint32(dst, uint32(offset))
+}
+
+// UnmarshalBool decodes a single byte bool, rejecting values other than 0 or 1.
+func UnmarshalBool(src []byte) (bool, error) {
+	if len(src) != 1 {
+		return false, ErrIncorrectSize
+	}
+	switch src[0] {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, ErrInvalidBool
+	}
+}
+
+// UnmarshalUint16 decodes a little-endian uint16.
+func UnmarshalUint16(src []byte) uint16 {
+	return binary.LittleEndian.Uint16(src)
+}
+
+// UnmarshalUint32 decodes a little-endian uint32.
+func UnmarshalUint32(src []byte) uint32 {
+	return binary.LittleEndian.Uint32(src)
+}
+
+// UnmarshalUint64 decodes a little-endian uint64.
+func UnmarshalUint64(src []byte) uint64 {
+	return binary.LittleEndian.Uint64(src)
+}
+
+// ReadOffset decodes a BytesPerLengthOffset-byte offset.
+func ReadOffset(src []byte) uint64 {
+	return uint64(binary.LittleEndian.Uint32(src))
+}
+
+// SplitOffsets divides the encoding of a list of variable-size items into the
+// encodings of each item, validating that every offset lies within the input and
+// that offsets never decrease. It fails if the list holds more than maxLength items.
+func SplitOffsets(buf []byte, maxLength uint64) ([][]byte, error) {
+	size := uint64(len(buf))
+	if size == 0 {
+		return [][]byte{}, nil
+	}
+	if size < BytesPerLengthOffset {
+		return nil, ErrInvalidOffset
+	}
+	firstOffset := ReadOffset(buf)
+	if firstOffset == 0 || firstOffset%BytesPerLengthOffset != 0 || firstOffset > size {
+		return nil, ErrInvalidOffset
+	}
+	num := firstOffset / BytesPerLengthOffset
+	if num > maxLength {
+		return nil, ErrListTooBig
+	}
+	items := make([][]byte, num)
+	start := firstOffset
+	for i := uint64(0); i < num; i++ {
+		end := size
+		if i+1 < num {
+			end = ReadOffset(buf[(i+1)*BytesPerLengthOffset:])
+		}
+		if end < start || end > size {
+			return nil, ErrInvalidOffset
+		}
+		items[i] = buf[start:end]
+		start = end
+	}
+	return items, nil
+}
+
+// ValidateBitlist checks that buf is a well-formed bitlist encoding holding
+// at most bitLimit bits.
+func ValidateBitlist(buf []byte, bitLimit uint64) error {
+	if len(buf) == 0 || buf[len(buf)-1] == 0 {
+		return ErrInvalidBitlist
+	}
+	if bitfield.Bitlist(buf).Len() > bitLimit {
+		return ErrListTooBig
+	}
+	return nil
+}
+
+// Pack splits the serialization of ordered basic values into BytesPerChunk-byte
+// chunks, right-padding the last chunk with zero bytes.
+func Pack(serialized []byte) [][]byte {
+	// pack never fails on a single flattened item.
+	chunks, _ := pack([][]byte{serialized})
+	return chunks
+}
+
+// Merkleize returns the Merkle root of the given chunks after padding them
+// with zero chunks up to limit leaves, using the Hasher set by SetHasher.
+func Merkleize(chunks [][]byte, limit uint64) ([32]byte, error) {
+	c := newHashContext(currentHasher())
+	defer c.release()
+	start := c.mark()
+	for _, chunk := range chunks {
+		c.chunks = append(c.chunks, chunk...)
+	}
+	return c.merkleize(start, limit, true /* has limit */)
+}
+
+// MixInLength mixes the length of a list into its Merkle root, using the Hasher
+// set by SetHasher.
+func MixInLength(root [32]byte, length uint64) [32]byte {
+	c := newHashContext(currentHasher())
+	defer c.release()
+	return c.mixIn(root, length)
+}
+
+// BitlistRoot computes the tree hash root of a bitlist with a maximum
+// capacity of maxCapacity bits, using the Hasher set by SetHasher.
+func BitlistRoot(bits bitfield.Bitlist, maxCapacity uint64) ([32]byte, error) {
+	c := newHashContext(currentHasher())
+	defer c.release()
+	return bitlistRoot(c, bits, maxCapacity)
+}
+
+func bitlistRoot(c *hashContext, bits bitfield.Bitlist, maxCapacity uint64) ([32]byte, error) {
+	limit := (maxCapacity + 255) / 256
+	if len(bits) == 0 {
+		merkleRoot, err := c.merkleize(c.mark(), limit, true /* has limit */)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		return c.mixIn(merkleRoot, 0), nil
+	}
+	start := c.mark()
+	c.chunks = append(c.chunks, bits.Bytes()...)
+	merkleRoot, err := c.merkleize(start, limit, true /* has limit */)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return c.mixIn(merkleRoot, bits.Len()), nil
+}
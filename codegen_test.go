@@ -0,0 +1,28 @@
+package ssz_test
+
+import (
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+func TestUnmarshalBool(t *testing.T) {
+	tests := []struct {
+		src     []byte
+		want    bool
+		wantErr error
+	}{
+		{src: []byte{0}, want: false},
+		{src: []byte{1}, want: true},
+		{src: []byte{2}, wantErr: ssz.ErrInvalidBool},
+		{src: []byte{}, wantErr: ssz.ErrIncorrectSize},
+		{src: nil, wantErr: ssz.ErrIncorrectSize},
+		{src: []byte{1, 0}, wantErr: ssz.ErrIncorrectSize},
+	}
+	for _, tt := range tests {
+		got, err := ssz.UnmarshalBool(tt.src)
+		if err != tt.wantErr || got != tt.want {
+			t.Errorf("UnmarshalBool(%v) = %v, %v, want %v, %v", tt.src, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package ssz
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"reflect"
+)
+
+// ErrMaxSizeExceeded is returned by a Decoder when its input is larger than the
+// maximum size it was created with.
+var ErrMaxSizeExceeded = errors.New("input exceeds maximum size")
+
+// Decoder reads SSZ encoded values from an input stream. Fixed-size parts and
+// offset tables are read as they are needed and the target value is filled in
+// as bytes arrive, so only values of fixed size, byte lists, unions and types
+// which decode themselves are buffered whole. A value of variable size extends
+// to the end of the stream, while a value of fixed size consumes exactly as many
+// bytes as its encoding takes up.
+type Decoder struct {
+	r       *bufio.Reader
+	maxSize uint64
+	read    uint64
+}
+
+// NewDecoder returns a new decoder that reads from r, refusing to read more than
+// maxSize bytes.
+func NewDecoder(r io.Reader, maxSize uint64) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), maxSize: maxSize}
+}
+
+// Decode reads an SSZ encoded value from the stream and stores it in the object
+// pointed to by val:
+//  f, err := os.Open("state.ssz")
+//  if err != nil {
+//      return err
+//  }
+//  defer f.Close()
+//  var state BeaconState
+//  if err := ssz.NewDecoder(f, maxStateSize).Decode(&state); err != nil {
+//      return fmt.Errorf("failed to decode state: %v", err)
+//  }
+func (d *Decoder) Decode(val interface{}) error {
+	if val == nil {
+		return errors.New("cannot decode into untyped, nil value")
+	}
+	rval := reflect.ValueOf(val)
+	if rval.Kind() != reflect.Ptr {
+		return errors.New("can only decode into a pointer target")
+	}
+	if rval.IsNil() {
+		return errors.New("cannot output to pointer of nil value")
+	}
+	typ := rval.Elem().Type()
+	utils, err := cachedSSZUtils(typ)
+	if err != nil {
+		return fmt.Errorf("could not initialize unmarshaler for type: %v, %v", typ, err)
+	}
+	start := d.read
+	if err := d.decode(rval.Elem(), typ, utils, 0, 0, true /* to EOF */); err != nil {
+		// Offsets are reported from the start of the stream, which may hold values
+		// decoded earlier.
+		return newDecodeError(withPath(err, "", start), typ)
+	}
+	return nil
+}
+
+// decode fills val, which is encoded as typ using utils. Its encoding is size bytes
+// long, or extends to the end of the stream if toEOF is set. Lists holding more than
+// maxCapacity items, if it is set, are rejected as soon as their length is known.
+func (d *Decoder) decode(val reflect.Value, typ reflect.Type, utils *sszUtils, size uint64, maxCapacity uint64, toEOF bool) error {
+	prepareForType(val, typ)
+	kind := typ.Kind()
+	switch {
+	case !isVariableSizeType(typ):
+		fixedSize := fixedTypeSize(typ)
+		if !toEOF && size != fixedSize {
+			return ErrIncorrectSize
+		}
+		buf, err := d.readFull(fixedSize)
+		if err != nil {
+			return err
+		}
+		_, err = utils.unmarshaler(buf, val, 0)
+		return err
+	case typ == unionType || implements(typ, unmarshalerType) || kind == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		buf, err := d.readSized(size, toEOF)
+		if err != nil {
+			return err
+		}
+		if typ == bitlistType {
+			limit := uint64(math.MaxUint64)
+			if maxCapacity > 0 {
+				limit = maxCapacity
+			}
+			if err := ValidateBitlist(buf, limit); err != nil {
+				return err
+			}
+		}
+		_, err = utils.unmarshaler(buf, val, 0)
+		return err
+	case kind == reflect.Ptr:
+		elemUtils, err := cachedSSZUtils(typ.Elem())
+		if err != nil {
+			return err
+		}
+		return d.decode(val.Elem(), typ.Elem(), elemUtils, size, maxCapacity, toEOF)
+	case (kind == reflect.Slice || kind == reflect.Array) && !isVariableSizeType(typ.Elem()):
+		return d.decodeBasicList(val, typ, size, maxCapacity, toEOF)
+	case kind == reflect.Slice || kind == reflect.Array:
+		return d.decodeCompositeList(val, typ, size, maxCapacity, toEOF)
+	case kind == reflect.Struct:
+		return d.decodeStruct(val, typ, size, toEOF)
+	default:
+		return fmt.Errorf("type %v is not deserializable", typ)
+	}
+}
+
+// decodeBasicList decodes a list of fixed-size elements one element at a time.
+func (d *Decoder) decodeBasicList(val reflect.Value, typ reflect.Type, size uint64, maxCapacity uint64, toEOF bool) error {
+	start := d.read
+	elemType := typ.Elem()
+	elemUtils, err := cachedSSZUtils(elemType)
+	if err != nil {
+		return err
+	}
+	elemSize := fixedTypeSize(elemType)
+	if elemSize == 0 {
+		return fmt.Errorf("cannot decode list of empty type %v", elemType)
+	}
+	if !toEOF && size%elemSize != 0 {
+		return ErrIncorrectSize
+	}
+	if val.Kind() == reflect.Slice {
+		val.Set(reflect.MakeSlice(val.Type(), 0, 0))
+	}
+	for i := 0; ; i++ {
+		if toEOF {
+			done, err := d.atEOF()
+			if err != nil {
+				return err
+			}
+			if done {
+				break
+			}
+		} else if uint64(i)*elemSize == size {
+			break
+		}
+		if maxCapacity > 0 {
+			if err := capacityError(uint64(i)+1, maxCapacity); err != nil {
+				return err
+			}
+		}
+		if val.Kind() == reflect.Slice {
+			val.Set(reflect.Append(val, reflect.Zero(val.Type().Elem())))
+		} else if i >= val.Len() {
+			return ErrIncorrectSize
+		}
+		elemStart := d.read
+		if err := d.decode(val.Index(i), elemType, elemUtils, elemSize, 0, false); err != nil {
+			return withPath(err, indexSegment(i), elemStart-start)
+		}
+	}
+	return nil
+}
+
+// decodeCompositeList decodes a list of variable-size elements, reading its offset
+// table before streaming the elements.
+func (d *Decoder) decodeCompositeList(val reflect.Value, typ reflect.Type, size uint64, maxCapacity uint64, toEOF bool) error {
+	start := d.read
+	elemType := typ.Elem()
+	elemUtils, err := cachedSSZUtils(elemType)
+	if err != nil {
+		return err
+	}
+	empty := !toEOF && size == 0
+	if toEOF {
+		if empty, err = d.atEOF(); err != nil {
+			return err
+		}
+	}
+	if empty {
+		if typ.Kind() == reflect.Array && typ.Len() != 0 {
+			return ErrIncorrectSize
+		}
+		if val.Kind() == reflect.Slice {
+			val.Set(reflect.MakeSlice(val.Type(), 0, 0))
+		}
+		return nil
+	}
+	first, err := d.readOffset()
+	if err != nil {
+		return err
+	}
+	if first == 0 || first%BytesPerLengthOffset != 0 || !toEOF && first > size {
+		return ErrInvalidOffset
+	}
+	count := first / BytesPerLengthOffset
+	if typ.Kind() == reflect.Array && uint64(typ.Len()) != count {
+		return ErrIncorrectSize
+	}
+	// The first offset gives the number of elements, which is checked before the
+	// rest of the offset table is read.
+	if maxCapacity > 0 {
+		if err := capacityError(count, maxCapacity); err != nil {
+			return err
+		}
+	}
+	offsets := []uint64{first}
+	for i := uint64(1); i < count; i++ {
+		offset, err := d.readOffset()
+		if err != nil {
+			return err
+		}
+		offsets = append(offsets, offset)
+	}
+	if val.Kind() == reflect.Slice {
+		val.Set(reflect.MakeSlice(val.Type(), int(count), int(count)))
+	}
+	sizes, err := offsetSizes(offsets, size, toEOF)
+	if err != nil {
+		return err
+	}
+	for i := range offsets {
+		last := i == len(offsets)-1
+		elemStart := d.read
+		if err := d.decode(val.Index(i), elemType, elemUtils, sizes[i], 0, toEOF && last); err != nil {
+			return withPath(err, indexSegment(i), elemStart-start)
+		}
+	}
+	return nil
+}
+
+// decodeStruct reads the fixed part of a struct, decoding its fixed-size fields and
+// offsets, before streaming its variable-size fields.
+func (d *Decoder) decodeStruct(val reflect.Value, typ reflect.Type, size uint64, toEOF bool) error {
+	fields, err := cachedStructFields(typ)
+	if err != nil {
+		return err
+	}
+	fixedLength := uint64(0)
+	for _, f := range fields {
+		if isVariableSizeType(f.typ) {
+			fixedLength += BytesPerLengthOffset
+		} else {
+			fixedLength += fixedTypeSize(f.typ)
+		}
+	}
+	if !toEOF && size < fixedLength {
+		return ErrIncorrectSize
+	}
+	start := d.read
+	fixed, err := d.readFull(fixedLength)
+	if err != nil {
+		return err
+	}
+	var offsets []uint64
+	var variableFields []field
+	index := uint64(0)
+	for _, f := range fields {
+		if isVariableSizeType(f.typ) {
+			offsets = append(offsets, uint64(binary.LittleEndian.Uint32(fixed[index:index+BytesPerLengthOffset])))
+			variableFields = append(variableFields, f)
+			index += BytesPerLengthOffset
+			continue
+		}
+		fieldSize := fixedTypeSize(f.typ)
+		fieldVal := val.Field(f.index)
+		prepareForType(fieldVal, f.typ)
+		if _, err := f.sszUtils.unmarshaler(fixed[index:index+fieldSize], fieldVal, 0); err != nil {
+			return withPath(err, fieldSegment(f.name), index)
+		}
+		index += fieldSize
+	}
+	if len(offsets) == 0 {
+		return nil
+	}
+	if offsets[0] != fixedLength {
+		return ErrInvalidOffset
+	}
+	sizes, err := offsetSizes(offsets, size, toEOF)
+	if err != nil {
+		return err
+	}
+	for i, f := range variableFields {
+		last := i == len(variableFields)-1
+		fieldStart := d.read
+		// Items beyond the capacity of the field are rejected before they are read.
+		if !(toEOF && last) {
+			if err := checkSizeCapacity(sizes[i], f); err != nil {
+				return withPath(err, fieldSegment(f.name), fieldStart-start)
+			}
+		}
+		if err := d.decode(val.Field(f.index), f.typ, f.sszUtils, sizes[i], f.capacity, toEOF && last); err != nil {
+			return withPath(err, fieldSegment(f.name), fieldStart-start)
+		}
+		if err := checkFieldCapacity(val.Field(f.index), f); err != nil {
+			return withPath(err, fieldSegment(f.name), fieldStart-start)
+		}
+	}
+	return nil
+}
+
+// offsetSizes returns the size of each part delimited by offsets, which are relative
+// to the start of an encoding of the given size. The last part has an unknown size
+// if the encoding extends to the end of the stream.
+func offsetSizes(offsets []uint64, size uint64, toEOF bool) ([]uint64, error) {
+	sizes := make([]uint64, len(offsets))
+	for i := range offsets {
+		end := size
+		if i+1 < len(offsets) {
+			end = offsets[i+1]
+		} else if toEOF {
+			break
+		}
+		if end < offsets[i] || !toEOF && end > size {
+			return nil, ErrInvalidOffset
+		}
+		sizes[i] = end - offsets[i]
+	}
+	return sizes, nil
+}
+
+// readFull reads exactly n bytes from the stream.
+func (d *Decoder) readFull(n uint64) ([]byte, error) {
+	if n > d.maxSize-d.read {
+		return nil, ErrMaxSizeExceeded
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(d.r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	d.read += n
+	return buf, nil
+}
+
+// readSized reads size bytes from the stream, or all remaining bytes if toEOF is set.
+func (d *Decoder) readSized(size uint64, toEOF bool) ([]byte, error) {
+	if !toEOF {
+		return d.readFull(size)
+	}
+	remaining := d.maxSize - d.read
+	limit := int64(math.MaxInt64)
+	if remaining < math.MaxInt64 {
+		limit = int64(remaining) + 1
+	}
+	buf, err := ioutil.ReadAll(io.LimitReader(d.r, limit))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(buf)) > remaining {
+		return nil, ErrMaxSizeExceeded
+	}
+	d.read += uint64(len(buf))
+	return buf, nil
+}
+
+func (d *Decoder) readOffset() (uint64, error) {
+	buf, err := d.readFull(BytesPerLengthOffset)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(binary.LittleEndian.Uint32(buf)), nil
+}
+
+// atEOF reports whether the stream has been fully consumed.
+func (d *Decoder) atEOF() (bool, error) {
+	_, err := d.r.Peek(1)
+	if err == io.EOF {
+		return true, nil
+	}
+	return false, err
+}
+
+// prepareForType allocates nil pointers and grows slices which are encoded as
+// arrays because of their struct tags, so that they can be unmarshaled into.
+func prepareForType(val reflect.Value, typ reflect.Type) {
+	switch {
+	case val.Kind() == reflect.Ptr && typ.Kind() == reflect.Ptr && val.IsNil():
+		instantiateConcreteTypeForElement(val, typ.Elem())
+	case val.Kind() == reflect.Slice && typ.Kind() == reflect.Array:
+		val.Set(reflect.MakeSlice(val.Type(), typ.Len(), typ.Len()))
+		for i := 0; i < typ.Len(); i++ {
+			prepareForType(val.Index(i), typ.Elem())
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package ssz_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+func TestDecoder_MatchesUnmarshal(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		ptr   interface{}
+	}{
+		{input: true, ptr: new(bool)},
+		{input: uint64(23929309), ptr: new(uint64)},
+		{input: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}, ptr: new([8]byte)},
+		{input: []byte{9, 8, 9, 8}, ptr: new([]byte)},
+		{input: [20][2]uint32{{3, 4}, {5}, {8}, {9, 10}}, ptr: new([20][2]uint32)},
+		{input: []uint64{1, 2, 3}, ptr: new([]uint64)},
+		{input: forkExample, ptr: new(fork)},
+		{input: nestedItemExample, ptr: new(nestedItem)},
+		{input: nestedVarItemExample, ptr: new(nestedVarItem)},
+		{input: nestedVarItem{Field1: []varItem{varItemExample, varItemAmbiguous}, Field2: 7}, ptr: new(nestedVarItem)},
+		{input: varItemAmbiguous, ptr: new(varItem)},
+		{input: []fork{forkExample, forkExample}, ptr: new([]fork)},
+		{input: [][]uint64{{4, 3, 2}, {1}, {0}}, ptr: new([][]uint64)},
+		{input: [][][]uint64{{{1, 2}, {3}}, {{4, 5}}, {{0}}}, ptr: new([][][]uint64)},
+		{input: [3][]uint64{{1, 2}, {4, 5, 6}, {7}}, ptr: new([3][]uint64)},
+		{input: []*fork{&forkExample, &forkExample}, ptr: new([]*fork)},
+		{input: []*nestedItem{&nestedItemExample, &nestedItemExample}, ptr: new([]*nestedItem)},
+		{
+			input: taggedItem{
+				Roots:    [][]byte{bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)},
+				Root:     bytes.Repeat([]byte{3}, 32),
+				Balance:  big.NewInt(1000),
+				Payload:  ssz.Union{Selector: 1, Value: uint64(4)},
+				Variable: []varItem{varItemExample},
+			},
+			ptr: new(taggedItem),
+		},
+	}
+	for _, tt := range tests {
+		encoded, err := ssz.Marshal(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := reflect.New(reflect.TypeOf(tt.ptr).Elem())
+		if err := ssz.Unmarshal(encoded, want.Interface()); err != nil {
+			t.Fatal(err)
+		}
+		// Reading a byte at a time checks that nothing relies on reads being filled.
+		r := iotest.OneByteReader(bytes.NewReader(encoded))
+		if err := ssz.NewDecoder(r, uint64(len(encoded))).Decode(tt.ptr); err != nil {
+			t.Fatalf("Decoding %v: %v", tt.input, err)
+		}
+		if !ssz.DeepEqual(want.Interface(), tt.ptr) {
+			t.Errorf("Expected %v, received %v", want.Elem().Interface(), reflect.ValueOf(tt.ptr).Elem().Interface())
+		}
+	}
+}
+
+func TestDecoder_MaxSizeExceeded(t *testing.T) {
+	encoded, err := ssz.Marshal(nestedVarItem{Field1: []varItem{varItemExample}, Field2: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, maxSize := range []uint64{0, 4, uint64(len(encoded)) - 1} {
+		var decoded nestedVarItem
+		if err := ssz.NewDecoder(bytes.NewReader(encoded), maxSize).Decode(&decoded); err == nil {
+			t.Errorf("Expected error decoding %d bytes with a maximum of %d, received nil", len(encoded), maxSize)
+		}
+	}
+}
+
+func TestDecoder_RejectsMalformedInput(t *testing.T) {
+	encoded, err := ssz.Marshal(varItemExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "Truncated", input: encoded[:5]},
+		{name: "FirstOffsetPastFixedPart", input: append([]byte{9}, encoded[1:]...)},
+		{name: "DecreasingOffsets", input: append([]byte{8, 0, 0, 0, 7}, encoded[5:]...)},
+		{name: "OddListLength", input: append(append([]byte{}, encoded...), 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded varItem
+			if err := ssz.NewDecoder(bytes.NewReader(tt.input), 100).Decode(&decoded); err == nil {
+				t.Errorf("Expected error decoding %v, received nil", tt.input)
+			}
+		})
+	}
+}
+
+func TestDecoder_RejectsOversizedListBeforeReadingIt(t *testing.T) {
+	// The offsets of cappedItem claim 1000 values, but only its fixed part follows,
+	// so the list must be rejected from its size rather than read.
+	input := []byte{20, 0, 0, 0}
+	for i := 0; i < 4; i++ {
+		input = append(input, 0x54, 0x1f, 0, 0)
+	}
+	var decoded cappedItem
+	err := ssz.NewDecoder(bytes.NewReader(input), 1<<20).Decode(&decoded)
+	if !errors.Is(err, ssz.ErrListTooBig) {
+		t.Errorf("Expected %v, received %v", ssz.ErrListTooBig, err)
+	}
+}
+
+func TestDecoder_RejectsOversizedLastListBeforeReadingIt(t *testing.T) {
+	// Nested, the last field of cappedItem, extends to the end of the stream. Its
+	// first offset claims 50000 elements, and none of the other offsets follow, so
+	// it must be rejected from that offset alone.
+	input := []byte{20, 0, 0, 0, 20, 0, 0, 0, 20, 0, 0, 0, 21, 0, 0, 0, 21, 0, 0, 0, 1}
+	input = append(input, 0x40, 0x0d, 0x03, 0x00)
+	var decoded cappedItem
+	err := ssz.NewDecoder(bytes.NewReader(input), 1<<20).Decode(&decoded)
+	if !errors.Is(err, ssz.ErrListTooBig) {
+		t.Errorf("Expected %v, received %v", ssz.ErrListTooBig, err)
+	}
+}
+
+func TestDecoder_RejectsMalformedBitlists(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "Empty", input: []byte{}},
+		{name: "NoLengthBit", input: []byte{0x05, 0x00}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded bitfield.Bitlist
+			err := ssz.NewDecoder(bytes.NewReader(tt.input), 100).Decode(&decoded)
+			if !errors.Is(err, ssz.ErrInvalidBitlist) {
+				t.Errorf("Expected %v, received %v", ssz.ErrInvalidBitlist, err)
+			}
+		})
+	}
+}
+
+func TestDecoder_RejectsVectorsOfWrongLength(t *testing.T) {
+	type lists struct {
+		Lists [][]uint64
+	}
+	type vector struct {
+		Lists [][]uint64 `ssz-size:"2,?"`
+	}
+	for _, n := range []int{0, 1, 3} {
+		encoded, err := ssz.Marshal(lists{Lists: make([][]uint64, n)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded vector
+		err = ssz.NewDecoder(bytes.NewReader(encoded), 100).Decode(&decoded)
+		if !errors.Is(err, ssz.ErrIncorrectSize) {
+			t.Errorf("Decoding %d lists: expected %v, received %v", n, ssz.ErrIncorrectSize, err)
+		}
+	}
+}
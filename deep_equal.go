@@ -1,6 +1,7 @@
 package ssz
 
 import (
+	"math/big"
 	"reflect"
 	"unsafe"
 )
@@ -106,9 +107,17 @@ func deepValueEqual(v1, v2 reflect.Value, visited map[visit]bool, depth int) boo
 		if v1.Pointer() == v2.Pointer() {
 			return true
 		}
+		if v1.Type() == bigIntType {
+			return bigIntEqual(v1.Interface().(*big.Int), v2.Interface().(*big.Int))
+		}
 		return deepValueEqual(v1.Elem(), v2.Elem(), visited, depth+1)
 	case reflect.Struct:
 		for i, n := 0, v1.NumField(); i < n; i++ {
+			// The roots remembered by a RootCache, and omitted fields, are not part of
+			// the value.
+			if isOmittedField(v1.Type().Field(i)) {
+				continue
+			}
 			if !deepValueEqual(v1.Field(i), v2.Field(i), visited, depth+1) {
 				return false
 			}
@@ -129,6 +138,18 @@ func deepValueEqual(v1, v2 reflect.Value, visited map[visit]bool, depth int) boo
 	}
 }
 
+// bigIntEqual compares big integers by value, as their representation is unexported.
+// A nil value encodes as zero, so it is equal to zero.
+func bigIntEqual(x, y *big.Int) bool {
+	if x == nil {
+		x = new(big.Int)
+	}
+	if y == nil {
+		y = new(big.Int)
+	}
+	return x.Cmp(y) == 0
+}
+
 // DeepEqual reports whether two SSZ-able values x and y are ``deeply equal,'' defined as follows:
 // Two values of identical type are deeply equal if one of the following cases applies:
 //
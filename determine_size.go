@@ -21,6 +21,9 @@ func isBasicTypeSlice(typ reflect.Type, kind reflect.Kind) bool {
 }
 
 func isVariableSizeType(typ reflect.Type) bool {
+	if typ == unionType {
+		return true
+	}
 	kind := typ.Kind()
 	switch {
 	case isBasicType(kind):
@@ -34,6 +37,9 @@ func isVariableSizeType(typ reflect.Type) bool {
 	case kind == reflect.Struct:
 		for i := 0; i < typ.NumField(); i++ {
 			f := typ.Field(i)
+			if isOmittedField(f) {
+				continue
+			}
 			fType, err := determineFieldType(f)
 			if err != nil {
 				return false
@@ -50,6 +56,11 @@ func isVariableSizeType(typ reflect.Type) bool {
 }
 
 func determineFixedSize(val reflect.Value, typ reflect.Type) uint64 {
+	if val.Type() == typ {
+		if size, ok := customSize(val); ok {
+			return size
+		}
+	}
 	kind := typ.Kind()
 	switch {
 	case kind == reflect.Bool:
@@ -92,9 +103,45 @@ func determineFixedSize(val reflect.Value, typ reflect.Type) uint64 {
 	}
 }
 
+// fixedTypeSize returns the encoded size of a fixed-size type. Unlike determineFixedSize,
+// it does not depend on a value, so nil pointers and slices which are encoded as
+// vectors because of their struct tags are sized by their type.
+func fixedTypeSize(typ reflect.Type) uint64 {
+	kind := typ.Kind()
+	if kind == reflect.Ptr {
+		return fixedTypeSize(typ.Elem())
+	}
+	if size, ok := customSize(reflect.New(typ).Elem()); ok {
+		return size
+	}
+	switch {
+	case kind == reflect.Array:
+		return uint64(typ.Len()) * fixedTypeSize(typ.Elem())
+	case kind == reflect.Struct:
+		totalSize := uint64(0)
+		fields, err := structFields(typ)
+		if err != nil {
+			return 0
+		}
+		for _, f := range fields {
+			totalSize += fixedTypeSize(f.typ)
+		}
+		return totalSize
+	default:
+		return determineFixedSize(reflect.New(typ).Elem(), typ)
+	}
+}
+
 func determineVariableSize(val reflect.Value, typ reflect.Type) uint64 {
+	if val.Type() == typ {
+		if size, ok := customSize(val); ok {
+			return size
+		}
+	}
 	kind := typ.Kind()
 	switch {
+	case typ == unionType:
+		return determineUnionSize(val)
 	case kind == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
 		return uint64(val.Len())
 	case kind == reflect.Slice || kind == reflect.Array:
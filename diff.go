@@ -0,0 +1,229 @@
+package ssz
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Difference is a value which differs between the two values compared by Diff.
+type Difference struct {
+	// Path locates the value, such as BeaconState.Validators[3].Pubkey, in the same
+	// form as the paths of errors.
+	Path string
+	// A and B are the values found in the first and in the second value compared. The
+	// elements past the end of the shorter of two lists are compared with nil.
+	A interface{}
+	B interface{}
+	// Equivalent is set when DeepEqual treats the values as equal, such as a nil and
+	// an empty slice, which have the same encoding.
+	Equivalent bool
+}
+
+// String formats the difference on a single line.
+func (d Difference) String() string {
+	s := fmt.Sprintf("%s: %s != %s", d.Path, formatDiffValue(d.A), formatDiffValue(d.B))
+	if d.Equivalent {
+		s += " (equivalent)"
+	}
+	return s
+}
+
+// Diff compares two SSZ-able values the way DeepEqual does, and returns where they
+// differ, including the differences DeepEqual ignores. Lists are compared element by
+// element, while byte slices and arrays, such as roots, are compared as a whole. Use
+// FormatDiff to report the differences in a test failure:
+//  if !ssz.DeepEqual(decoded, want) {
+//      t.Errorf("Decoded value differs:\n%s", ssz.FormatDiff(ssz.Diff(decoded, want)))
+//  }
+func Diff(a, b interface{}) []Difference {
+	if a == nil || b == nil {
+		if a == b {
+			return nil
+		}
+		return []Difference{{Path: "", A: a, B: b}}
+	}
+	d := &differ{}
+	d.diff(rootPathName(reflect.TypeOf(a)), reflect.ValueOf(a), reflect.ValueOf(b))
+	return d.diffs
+}
+
+// rootPathName names the root of the paths of differences between values of type typ,
+// such as BeaconState for a *BeaconState.
+func rootPathName(typ reflect.Type) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Name() != "" {
+		return typ.Name()
+	}
+	return typ.String()
+}
+
+// FormatDiff formats differences as a report with one difference per line.
+func FormatDiff(diffs []Difference) string {
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+type differ struct {
+	diffs []Difference
+}
+
+func (d *differ) add(path string, v1, v2 reflect.Value, equivalent bool) {
+	d.diffs = append(d.diffs, Difference{
+		Path:       path,
+		A:          diffValue(v1),
+		B:          diffValue(v2),
+		Equivalent: equivalent,
+	})
+}
+
+func (d *differ) diff(path string, v1, v2 reflect.Value) {
+	if v1.Type() != v2.Type() {
+		d.add(path, v1, v2, false)
+		return
+	}
+	switch v1.Kind() {
+	case reflect.Array:
+		if v1.Type().Elem().Kind() == reflect.Uint8 {
+			if !bytesValueEqual(v1, v2) {
+				d.add(path, v1, v2, false)
+			}
+			return
+		}
+		for i := 0; i < v1.Len(); i++ {
+			d.diff(path+indexSegment(i), v1.Index(i), v2.Index(i))
+		}
+	case reflect.Slice:
+		if v1.Len() == 0 && v2.Len() == 0 {
+			if v1.IsNil() != v2.IsNil() {
+				d.add(path, v1, v2, true /* equivalent */)
+			}
+			return
+		}
+		if v1.Type().Elem().Kind() == reflect.Uint8 {
+			if !bytesValueEqual(v1, v2) {
+				d.add(path, v1, v2, false)
+			}
+			return
+		}
+		n := v1.Len()
+		if v2.Len() < n {
+			n = v2.Len()
+		}
+		for i := 0; i < n; i++ {
+			d.diff(path+indexSegment(i), v1.Index(i), v2.Index(i))
+		}
+		for i := n; i < v1.Len(); i++ {
+			d.add(path+indexSegment(i), v1.Index(i), reflect.Value{}, false)
+		}
+		for i := n; i < v2.Len(); i++ {
+			d.add(path+indexSegment(i), reflect.Value{}, v2.Index(i), false)
+		}
+	case reflect.Interface:
+		if v1.IsNil() || v2.IsNil() {
+			if v1.IsNil() != v2.IsNil() {
+				d.add(path, v1, v2, false)
+			}
+			return
+		}
+		d.diff(path, v1.Elem(), v2.Elem())
+	case reflect.Ptr:
+		if v1.Type() == bigIntType && v1.CanInterface() {
+			x, y := v1.Interface().(*big.Int), v2.Interface().(*big.Int)
+			if x == y {
+				return
+			}
+			// A nil big integer encodes as zero, so DeepEqual treats it as equal to zero.
+			if equal := bigIntEqual(x, y); !equal || x == nil || y == nil {
+				d.add(path, v1, v2, equal)
+			}
+			return
+		}
+		if v1.IsNil() || v2.IsNil() {
+			if v1.IsNil() != v2.IsNil() {
+				d.add(path, v1, v2, false)
+			}
+			return
+		}
+		d.diff(path, v1.Elem(), v2.Elem())
+	case reflect.Struct:
+		typ := v1.Type()
+		for i := 0; i < v1.NumField(); i++ {
+			f := typ.Field(i)
+			if isOmittedField(f) {
+				continue
+			}
+			d.diff(path+fieldSegment(f.Name), v1.Field(i), v2.Field(i))
+		}
+	case reflect.Bool:
+		if v1.Bool() != v2.Bool() {
+			d.add(path, v1, v2, false)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v1.Uint() != v2.Uint() {
+			d.add(path, v1, v2, false)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v1.Int() != v2.Int() {
+			d.add(path, v1, v2, false)
+		}
+	case reflect.String:
+		if v1.String() != v2.String() {
+			d.add(path, v1, v2, false)
+		}
+	default:
+		// Other kinds are not SSZ-able, and DeepEqual never treats them as equal.
+		d.add(path, v1, v2, false)
+	}
+}
+
+// bytesValueEqual compares two byte slices or arrays of the same type.
+func bytesValueEqual(v1, v2 reflect.Value) bool {
+	if v1.Len() != v2.Len() {
+		return false
+	}
+	for i := 0; i < v1.Len(); i++ {
+		if v1.Index(i).Uint() != v2.Index(i).Uint() {
+			return false
+		}
+	}
+	return true
+}
+
+// diffValue returns the value held by v, which may be read from an unexported field.
+func diffValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprint(v)
+}
+
+// formatDiffValue formats bytes in hexadecimal, and tells nil slices from empty ones.
+func formatDiffValue(v interface{}) string {
+	if v == nil {
+		return "nil"
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return "nil"
+	}
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() == reflect.Uint8 {
+		if rv.Len() == 0 {
+			return "[]"
+		}
+		return fmt.Sprintf("%#x", v)
+	}
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "nil"
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,90 @@
+package ssz_test
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type diffValidator struct {
+	Pubkey  [4]byte
+	Balance uint64
+}
+
+type diffState struct {
+	Slot       uint64
+	Validators []diffValidator
+	Roots      []byte
+	Total      *big.Int `ssz-type:"uint256"`
+	Extra      ssz.Union
+}
+
+func TestDiff(t *testing.T) {
+	a := diffState{
+		Slot:       1,
+		Validators: []diffValidator{{Pubkey: [4]byte{1}, Balance: 32}, {Balance: 1}},
+		Total:      big.NewInt(7),
+		Extra:      ssz.Union{Selector: 1, Value: uint64(2)},
+	}
+	b := diffState{
+		Slot:       2,
+		Validators: []diffValidator{{Pubkey: [4]byte{2}, Balance: 32}},
+		Roots:      []byte{},
+		Total:      big.NewInt(7),
+		Extra:      ssz.Union{Selector: 1, Value: uint64(3)},
+	}
+	want := []ssz.Difference{
+		{Path: "diffState.Slot", A: uint64(1), B: uint64(2)},
+		{Path: "diffState.Validators[0].Pubkey", A: [4]byte{1}, B: [4]byte{2}},
+		{Path: "diffState.Validators[1]", A: diffValidator{Balance: 1}, B: nil},
+		{Path: "diffState.Roots", A: []byte(nil), B: []byte{}, Equivalent: true},
+		{Path: "diffState.Extra.Value", A: uint64(2), B: uint64(3)},
+	}
+	diffs := ssz.Diff(a, b)
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("Diff() =\n%s\nwant\n%s", ssz.FormatDiff(diffs), ssz.FormatDiff(want))
+	}
+	// Paths start from the type pointed to.
+	if diffs := ssz.Diff(&a, &b); !reflect.DeepEqual(diffs, want) {
+		t.Errorf("Diff() of pointers =\n%s\nwant\n%s", ssz.FormatDiff(diffs), ssz.FormatDiff(want))
+	}
+	if diffs := ssz.Diff(&a, &a); len(diffs) != 0 {
+		t.Errorf("Expected no differences between a value and itself, received\n%s", ssz.FormatDiff(diffs))
+	}
+}
+
+func TestDiff_EquivalentValues(t *testing.T) {
+	a := &diffState{Validators: []diffValidator{}, Total: nil}
+	b := &diffState{Total: big.NewInt(0)}
+	if !ssz.DeepEqual(a, b) {
+		t.Fatal("Expected values to be deeply equal")
+	}
+	diffs := ssz.Diff(a, b)
+	if len(diffs) != 2 {
+		t.Fatalf("Expected 2 differences, received\n%s", ssz.FormatDiff(diffs))
+	}
+	for _, d := range diffs {
+		if !d.Equivalent {
+			t.Errorf("Expected %s to be equivalent", d)
+		}
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	report := ssz.FormatDiff([]ssz.Difference{
+		{Path: "State.Root", A: [2]byte{0xab, 0xcd}, B: [2]byte{0xab, 0xce}},
+		{Path: "State.Roots", A: []byte(nil), B: []byte{}, Equivalent: true},
+		{Path: "State.Balances[3]", A: uint64(5), B: nil},
+	})
+	want := strings.Join([]string{
+		"State.Root: 0xabcd != 0xabce",
+		"State.Roots: nil != [] (equivalent)",
+		"State.Balances[3]: 5 != nil",
+	}, "\n")
+	if report != want {
+		t.Errorf("FormatDiff() =\n%s\nwant\n%s", report, want)
+	}
+}
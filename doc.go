@@ -9,9 +9,12 @@ Currently directly supported types:
   uint16
   uint32
   uint64
+  uint128 (Uint128, or *big.Int tagged ssz-type:"uint128")
+  uint256 (Uint256, or *big.Int tagged ssz-type:"uint256")
   bytes
   slice
   struct
   ptr
+  union (Union, with variants declared by an ssz-union tag)
 */
 package ssz
@@ -0,0 +1,184 @@
+package ssz
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Encoder writes SSZ encodings to an output stream. Unlike Marshal, it never
+// holds the full encoding of a value in memory: offsets are computed ahead of
+// time and fields, list elements and variable-size tails are written to the
+// stream as they are encoded. Only values of fixed size, and types which encode
+// themselves, are buffered whole before being written.
+type Encoder struct {
+	w       *bufio.Writer
+	scratch []byte
+	written uint64
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes the SSZ encoding of val to the stream. It produces the same
+// output as Marshal:
+//  f, err := os.Create("state.ssz")
+//  if err != nil {
+//      return err
+//  }
+//  defer f.Close()
+//  if err := ssz.NewEncoder(f).Encode(state); err != nil {
+//      return fmt.Errorf("failed to encode state: %v", err)
+//  }
+func (e *Encoder) Encode(val interface{}) error {
+	if val == nil {
+		return errors.New("untyped-value nil cannot be encoded")
+	}
+	rval := reflect.ValueOf(val)
+	utils, err := cachedSSZUtils(rval.Type())
+	if err != nil {
+		return fmt.Errorf("could not initialize marshaler for type: %v, %v", rval.Type(), err)
+	}
+	e.written = 0
+	if err := e.encode(rval, rval.Type(), utils); err != nil {
+		return newEncodeError(err, rval.Type())
+	}
+	return e.w.Flush()
+}
+
+// encode writes val, which is encoded as typ using utils. The type may differ from
+// the type of val when it was inferred from struct tags.
+func (e *Encoder) encode(val reflect.Value, typ reflect.Type, utils *sszUtils) error {
+	kind := typ.Kind()
+	switch {
+	case !isVariableSizeType(typ) || typ == unionType || implements(typ, marshalerType):
+		// These values are bounded by their type, or encode themselves, so we
+		// marshal them into the scratch buffer.
+		return e.marshal(val, typ, utils)
+	case kind == reflect.Ptr:
+		if val.IsNil() {
+			return nil
+		}
+		elemUtils, err := cachedSSZUtils(typ.Elem())
+		if err != nil {
+			return err
+		}
+		return e.encode(val.Elem(), typ.Elem(), elemUtils)
+	case kind == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		return e.write(val.Bytes())
+	case kind == reflect.Slice || kind == reflect.Array:
+		return e.encodeList(val, typ)
+	case kind == reflect.Struct:
+		return e.encodeStruct(val, typ)
+	default:
+		return fmt.Errorf("type %v is not serializable", typ)
+	}
+}
+
+func (e *Encoder) marshal(val reflect.Value, typ reflect.Type, utils *sszUtils) error {
+	var size uint64
+	if isVariableSizeType(typ) {
+		size = determineVariableSize(val, typ)
+	} else {
+		size = determineFixedSize(val, typ)
+	}
+	if uint64(cap(e.scratch)) < size {
+		e.scratch = make([]byte, size)
+	}
+	buf := e.scratch[:size]
+	for i := range buf {
+		buf[i] = 0
+	}
+	if _, err := utils.marshaler(val, buf, 0); err != nil {
+		return err
+	}
+	return e.write(buf)
+}
+
+func (e *Encoder) encodeList(val reflect.Value, typ reflect.Type) error {
+	start := e.written
+	elemType := typ.Elem()
+	elemUtils, err := cachedSSZUtils(elemType)
+	if err != nil {
+		return err
+	}
+	if isVariableSizeType(elemType) {
+		// The offsets are written in a first pass, so the elements can be streamed
+		// without holding on to their sizes.
+		offset := uint64(val.Len()) * BytesPerLengthOffset
+		for i := 0; i < val.Len(); i++ {
+			if err := e.writeOffset(offset); err != nil {
+				return err
+			}
+			offset += determineVariableSize(val.Index(i), elemType)
+		}
+	}
+	for i := 0; i < val.Len(); i++ {
+		elemStart := e.written
+		if err := e.encode(val.Index(i), elemType, elemUtils); err != nil {
+			return withPath(err, indexSegment(i), elemStart-start)
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeStruct(val reflect.Value, typ reflect.Type) error {
+	fields, err := cachedStructFields(typ)
+	if err != nil {
+		return err
+	}
+	start := e.written
+	fixedLength := uint64(0)
+	for _, f := range fields {
+		if isVariableSizeType(f.typ) {
+			fixedLength += BytesPerLengthOffset
+		} else {
+			fixedLength += determineFixedSize(val.Field(f.index), f.typ)
+		}
+	}
+	// The fixed part holds fixed-size fields in place and offsets to the variable-size ones.
+	offset := fixedLength
+	for _, f := range fields {
+		if !isVariableSizeType(f.typ) {
+			fieldStart := e.written
+			if err := e.marshal(val.Field(f.index), f.typ, f.sszUtils); err != nil {
+				return withPath(err, fieldSegment(f.name), fieldStart-start)
+			}
+			continue
+		}
+		if err := checkFieldCapacity(val.Field(f.index), f); err != nil {
+			return withPath(err, fieldSegment(f.name), offset)
+		}
+		if err := e.writeOffset(offset); err != nil {
+			return err
+		}
+		offset += determineVariableSize(val.Field(f.index), f.typ)
+	}
+	for _, f := range fields {
+		if !isVariableSizeType(f.typ) {
+			continue
+		}
+		fieldStart := e.written
+		if err := e.encode(val.Field(f.index), f.typ, f.sszUtils); err != nil {
+			return withPath(err, fieldSegment(f.name), fieldStart-start)
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) writeOffset(offset uint64) error {
+	buf := make([]byte, BytesPerLengthOffset)
+	binary.LittleEndian.PutUint32(buf, uint32(offset))
+	return e.write(buf)
+}
+
+func (e *Encoder) write(buf []byte) error {
+	n, err := e.w.Write(buf)
+	e.written += uint64(n)
+	return err
+}
@@ -0,0 +1,86 @@
+package ssz_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type taggedItem struct {
+	Roots    [][]byte  `ssz-size:"?,32" ssz-max:"16"`
+	Root     []byte    `ssz-size:"32"`
+	Balance  *big.Int  `ssz-type:"uint256"`
+	Payload  ssz.Union `ssz-union:"None,uint64,unionCheckpoint"`
+	Variable []varItem
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestEncoder_MatchesMarshal(t *testing.T) {
+	tests := []interface{}{
+		true,
+		uint64(23929309),
+		[8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+		[]byte{9, 8, 9, 8},
+		[20][2]uint32{{3, 4}, {5}, {8}, {9, 10}},
+		[]uint64{1, 2, 3},
+		[]bool{},
+		forkExample,
+		nestedItemExample,
+		nestedVarItemExample,
+		nestedVarItem{Field1: []varItem{varItemExample, varItemAmbiguous}, Field2: 7},
+		varItemAmbiguous,
+		[]fork{forkExample, forkExample},
+		[][]uint64{{4, 3, 2}, {1}, {0}},
+		[][][]uint64{{{1, 2}, {3}}, {{4, 5}}, {{0}}},
+		[3][]uint64{{1, 2}, {4, 5, 6}, {7}},
+		&nestedItemExample,
+		[]*nestedItem{&nestedItemExample, &nestedItemExample},
+		[2]*nestedItem{&nestedItemExample, &nestedItemExample},
+		taggedItem{
+			Roots:    [][]byte{bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)},
+			Root:     bytes.Repeat([]byte{3}, 32),
+			Balance:  big.NewInt(1000),
+			Payload:  ssz.Union{Selector: 1, Value: uint64(4)},
+			Variable: []varItem{varItemExample},
+		},
+		customContainer{
+			Fixed:    bigEndianUint{Val: 1},
+			Variable: reversedBytes{Data: []byte{1, 2}},
+			Nested:   []reversedBytes{{Data: []byte{3, 4}}},
+		},
+	}
+	for _, tt := range tests {
+		want, err := ssz.Marshal(tt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		if err := ssz.NewEncoder(buf).Encode(tt); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("Encoding %v: expected %v, received %v", tt, want, buf.Bytes())
+		}
+	}
+}
+
+func TestEncoder_WriterError(t *testing.T) {
+	val := make([]uint64, 10000)
+	if err := ssz.NewEncoder(failingWriter{}).Encode(val); err == nil {
+		t.Error("Expected error from failing writer, received nil")
+	}
+}
+
+func TestEncoder_Nil(t *testing.T) {
+	if err := ssz.NewEncoder(new(bytes.Buffer)).Encode(nil); err == nil {
+		t.Error("Expected error encoding untyped nil, received nil")
+	}
+}
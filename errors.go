@@ -0,0 +1,141 @@
+package ssz
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// DecodeError is returned by Unmarshal and Decoder.Decode when their input cannot be
+// decoded into the target type. It records where in the value and in the input the
+// failure happened, and wraps its cause so that errors.Is and errors.As can be used
+// to classify it:
+//  var decodeErr *ssz.DecodeError
+//  if errors.As(err, &decodeErr) && errors.Is(err, ssz.ErrListTooBig) {
+//      log.Printf("peer sent oversized list at %s", decodeErr.Path)
+//  }
+type DecodeError struct {
+	// Type is the type which was being decoded.
+	Type reflect.Type
+	// Path locates the value which failed within Type, such as
+	// BeaconState.Validators[3].Pubkey.
+	Path string
+	// Offset is the position in the input of the encoding of the value which failed.
+	Offset uint64
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("could not decode %s at byte %d: %v", e.Path, e.Offset, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// EncodeError is returned by Marshal and Encoder.Encode when a value cannot be encoded.
+type EncodeError struct {
+	// Type is the type which was being encoded.
+	Type reflect.Type
+	// Path locates the value which failed within Type.
+	Path string
+	// Offset is the position in the output at which the value which failed was being
+	// encoded.
+	Offset uint64
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *EncodeError) Error() string {
+	return fmt.Sprintf("could not encode %s at byte %d: %v", e.Path, e.Offset, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// HashError is returned by HashTreeRoot and its variants when the root of a value
+// cannot be computed.
+type HashError struct {
+	// Type is the type which was being hashed.
+	Type reflect.Type
+	// Path locates the value which failed within Type.
+	Path string
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *HashError) Error() string {
+	return fmt.Sprintf("could not tree hash %s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *HashError) Unwrap() error {
+	return e.Err
+}
+
+// pathError records where within a value an error happened while it travels up
+// through the marshalers, unmarshalers and hashers of the enclosing values. Its
+// offset is relative to the start of the encoding of the value it was last
+// wrapped by.
+type pathError struct {
+	path   string
+	offset uint64
+	err    error
+}
+
+func (e *pathError) Error() string {
+	return fmt.Sprintf("%s: %v", e.path, e.err)
+}
+
+func (e *pathError) Unwrap() error {
+	return e.err
+}
+
+// withPath records that err happened in the part of a value reached through the
+// given path segment, whose encoding starts offset bytes into the encoding of the
+// value.
+func withPath(err error, segment string, offset uint64) error {
+	if pe, ok := err.(*pathError); ok {
+		return &pathError{path: segment + pe.path, offset: offset + pe.offset, err: pe.err}
+	}
+	return &pathError{path: segment, offset: offset, err: err}
+}
+
+func fieldSegment(name string) string {
+	return "." + name
+}
+
+func indexSegment(i int) string {
+	return fmt.Sprintf("[%d]", i)
+}
+
+// splitPath returns the path of err within a value of type typ, its offset and its
+// cause.
+func splitPath(err error, typ reflect.Type) (string, uint64, error) {
+	name := typ.Name()
+	if name == "" {
+		name = typ.String()
+	}
+	if pe, ok := err.(*pathError); ok {
+		return name + pe.path, pe.offset, pe.err
+	}
+	return name, 0, err
+}
+
+func newDecodeError(err error, typ reflect.Type) error {
+	path, offset, cause := splitPath(err, typ)
+	return &DecodeError{Type: typ, Path: path, Offset: offset, Err: cause}
+}
+
+func newEncodeError(err error, typ reflect.Type) error {
+	path, offset, cause := splitPath(err, typ)
+	return &EncodeError{Type: typ, Path: path, Offset: offset, Err: cause}
+}
+
+func newHashError(err error, typ reflect.Type) error {
+	path, _, cause := splitPath(err, typ)
+	return &HashError{Type: typ, Path: path, Err: cause}
+}
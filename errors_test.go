@@ -0,0 +1,109 @@
+package ssz_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type errorsContainer struct {
+	Slot  uint64
+	Items []cappedContainer `ssz-max:"4"`
+}
+
+func TestDecodeError_PathAndOffset(t *testing.T) {
+	// The second item holds two uint16 values, more than its ssz-max of 1.
+	item := errorsContainer{Slot: 1, Items: []cappedContainer{{Items: []uint16{1}}, {Items: []uint16{1}}}}
+	encoded, err := ssz.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Grow the items list of the second element by one value.
+	encoded = append(encoded, 2, 0)
+
+	for _, strict := range []bool{true, false} {
+		ssz.ToggleStrictUnmarshal(strict)
+		var decoded errorsContainer
+		err := ssz.Unmarshal(encoded, &decoded)
+		var decodeErr *ssz.DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Fatalf("Expected a *DecodeError, received %v", err)
+		}
+		if decodeErr.Path != "errorsContainer.Items[1].Items" {
+			t.Errorf("Expected path errorsContainer.Items[1].Items, received %s", decodeErr.Path)
+		}
+		// The slot and the offset of the items precede the list, whose two offsets
+		// and first element of 6 bytes precede the second element, whose single
+		// offset precedes its items.
+		if want := uint64(8 + 4 + 8 + 6 + 4); decodeErr.Offset != want {
+			t.Errorf("Expected offset %d, received %d", want, decodeErr.Offset)
+		}
+		if decodeErr.Type != reflect.TypeOf(errorsContainer{}) {
+			t.Errorf("Expected type errorsContainer, received %v", decodeErr.Type)
+		}
+		if !errors.Is(err, ssz.ErrListTooBig) {
+			t.Errorf("Expected error to wrap ErrListTooBig, received %v", err)
+		}
+	}
+	ssz.ToggleStrictUnmarshal(true)
+
+	var decoded errorsContainer
+	err = ssz.NewDecoder(bytes.NewReader(encoded), uint64(len(encoded))).Decode(&decoded)
+	var decodeErr *ssz.DecodeError
+	if !errors.As(err, &decodeErr) || !errors.Is(err, ssz.ErrListTooBig) {
+		t.Fatalf("Expected a *DecodeError wrapping ErrListTooBig, received %v", err)
+	}
+	if decodeErr.Path != "errorsContainer.Items[1].Items" {
+		t.Errorf("Expected path errorsContainer.Items[1].Items, received %s", decodeErr.Path)
+	}
+}
+
+func TestDecodeError_InvalidOffset(t *testing.T) {
+	encoded, err := ssz.Marshal(varItemExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded[4] = 255
+	var decoded varItem
+	err = ssz.Unmarshal(encoded, &decoded)
+	var decodeErr *ssz.DecodeError
+	if !errors.As(err, &decodeErr) || !errors.Is(err, ssz.ErrInvalidOffset) {
+		t.Fatalf("Expected a *DecodeError wrapping ErrInvalidOffset, received %v", err)
+	}
+	if decodeErr.Offset != 4 {
+		t.Errorf("Expected the offset of the second offset, 4, received %d", decodeErr.Offset)
+	}
+}
+
+func TestEncodeError_PathAndOffset(t *testing.T) {
+	item := errorsContainer{Slot: 1, Items: []cappedContainer{{Items: []uint16{1}}, {Items: []uint16{1, 2}}}}
+	_, marshalErr := ssz.Marshal(item)
+	encodeErr := ssz.NewEncoder(new(bytes.Buffer)).Encode(item)
+	for _, err := range []error{marshalErr, encodeErr} {
+		var target *ssz.EncodeError
+		if !errors.As(err, &target) || !errors.Is(err, ssz.ErrListTooBig) {
+			t.Fatalf("Expected an *EncodeError wrapping ErrListTooBig, received %v", err)
+		}
+		if target.Path != "errorsContainer.Items[1].Items" {
+			t.Errorf("Expected path errorsContainer.Items[1].Items, received %s", target.Path)
+		}
+		if want := uint64(8 + 4 + 8 + 6 + 4); target.Offset != want {
+			t.Errorf("Expected offset %d, received %d", want, target.Offset)
+		}
+	}
+}
+
+func TestHashError_Path(t *testing.T) {
+	item := taggedItem{Root: make([]byte, 32), Payload: ssz.Union{Selector: 5}}
+	_, err := ssz.HashTreeRoot(item)
+	var hashErr *ssz.HashError
+	if !errors.As(err, &hashErr) || !errors.Is(err, ssz.ErrUnknownSelector) {
+		t.Fatalf("Expected a *HashError wrapping ErrUnknownSelector, received %v", err)
+	}
+	if hashErr.Path != "taggedItem.Payload" {
+		t.Errorf("Expected path taggedItem.Payload, received %s", hashErr.Path)
+	}
+}
@@ -0,0 +1,24 @@
+package ssz_test
+
+// testValidator is the validator of the states shared by the tests, shaped like the
+// validators of the beacon chain.
+type testValidator struct {
+	Pubkey  []byte `ssz-size:"48"`
+	Balance uint64
+	Slashed bool
+}
+
+// newTestValidator returns the i-th of a set of distinct validators.
+func newTestValidator(i int) testValidator {
+	return testValidator{
+		Pubkey:  append([]byte{byte(i), byte(i >> 8)}, make([]byte, 46)...),
+		Balance: uint64(i) * 1000,
+		Slashed: i%7 == 0,
+	}
+}
+
+// testCheckpoint is the checkpoint of the states shared by the tests.
+type testCheckpoint struct {
+	Epoch uint64
+	Root  [32]byte
+}
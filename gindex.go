@@ -0,0 +1,179 @@
+package ssz
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LengthPathElement is the pseudo-field which refers to the length mixed into the root
+// of a list when used in the path passed to GeneralizedIndex.
+const LengthPathElement = "__len__"
+
+var boolType = reflect.TypeOf(false)
+
+// GeneralizedIndex returns the generalized index of the node reached by following path
+// down the Merkle tree of values of type typ, which can then be passed to Prove. Each
+// element of the path is either the name of a struct field, matched against its Go name
+// ignoring case and underscores, an integer index into a list or vector, or the
+// LengthPathElement pseudo-field of a list. Lists must declare their capacity with an
+// ssz-max tag, as the shape of their tree depends on it:
+//  gindex, err := GeneralizedIndex(reflect.TypeOf(BeaconState{}), "validators", 12, "effective_balance")
+//  if err != nil {
+//      return fmt.Errorf("failed to compute generalized index: %v", err)
+//  }
+// An index into a list or vector of basic values refers to the chunk the value is
+// packed into, which is shared with its neighbours.
+func GeneralizedIndex(typ reflect.Type, path ...interface{}) (uint64, error) {
+	if typ == nil {
+		return 0, errors.New("untyped nil is not supported")
+	}
+	gindex := uint64(1)
+	capacity := uint64(0)
+	isBitlist := false
+	for i, elem := range path {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		var err error
+		if elem == LengthPathElement {
+			if i != len(path)-1 {
+				return 0, errors.New("the length of a list has no children")
+			}
+			if typ.Kind() != reflect.Slice {
+				return 0, fmt.Errorf("type %v has no length", typ)
+			}
+			return concatGeneralizedIndex(gindex, 1, 1)
+		}
+		switch {
+		case typ == unionType || implements(typ, hashRootType) || isBasicType(typ.Kind()) || isBasicUintType(typ):
+			return 0, fmt.Errorf("cannot descend into type %v with path element %v", typ, elem)
+		case typ.Kind() == reflect.Struct:
+			name, ok := elem.(string)
+			if !ok {
+				return 0, fmt.Errorf("expected field name to descend into struct %v, received %v", typ, elem)
+			}
+			var f field
+			gindex, f, err = fieldGeneralizedIndex(gindex, typ, name)
+			typ, capacity, isBitlist = f.typ, f.capacity, f.typ == bitlistType
+		case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
+			index, ok := pathIndex(elem)
+			if !ok {
+				return 0, fmt.Errorf("expected index to descend into %v, received %v", typ, elem)
+			}
+			gindex, err = elementGeneralizedIndex(gindex, typ, capacity, isBitlist, index)
+			if isBitlist {
+				typ = boolType
+			} else {
+				typ = typ.Elem()
+			}
+			// Elements of lists never carry a capacity of their own.
+			capacity, isBitlist = 0, false
+		default:
+			return 0, fmt.Errorf("type %v is not hashable", typ)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return gindex, nil
+}
+
+// fieldGeneralizedIndex descends from the root of a struct at gindex into the named field.
+func fieldGeneralizedIndex(gindex uint64, typ reflect.Type, name string) (uint64, field, error) {
+	fields, err := cachedStructFields(typ)
+	if err != nil {
+		return 0, field{}, err
+	}
+	i, ok := fieldIndex(fields, name)
+	if !ok {
+		return 0, field{}, fmt.Errorf("struct %v has no field %s", typ, name)
+	}
+	gindex, err = concatGeneralizedIndex(gindex, treeDepth(uint64(len(fields))), uint64(i))
+	return gindex, fields[i], err
+}
+
+// fieldIndex returns the position among fields of the field named name, matched
+// against its Go name ignoring case and underscores.
+func fieldIndex(fields []field, name string) (int, bool) {
+	for i, f := range fields {
+		if normalizeFieldName(f.name) == normalizeFieldName(name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeFieldName lowers the case of a field name and removes its underscores, so
+// that Go names match the names of the specification.
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+// elementGeneralizedIndex descends from the root of a list or vector at gindex into the
+// chunk holding the element at index.
+func elementGeneralizedIndex(gindex uint64, typ reflect.Type, capacity uint64, isBitlist bool, index uint64) (uint64, error) {
+	length := uint64(0)
+	if typ.Kind() == reflect.Array {
+		length = uint64(typ.Len())
+	} else {
+		if capacity == 0 {
+			return 0, fmt.Errorf("list of type %v has no ssz-max capacity", typ)
+		}
+		length = capacity
+		// The contents of a list are the left child of its root.
+		var err error
+		if gindex, err = concatGeneralizedIndex(gindex, 1, 0); err != nil {
+			return 0, err
+		}
+	}
+	if index >= length {
+		return 0, fmt.Errorf("index %d out of range for %v of length %d", index, typ, length)
+	}
+	// Basic values are packed into chunks, unlike composite values which take up a
+	// chunk each.
+	chunks, chunk := length, index
+	elemType := typ.Elem()
+	switch {
+	case isBitlist:
+		chunks, chunk = (length+255)/256, index/256
+	case isBasicType(elemType.Kind()) || isBasicUintType(elemType):
+		elemSize := fixedTypeSize(elemType)
+		chunks, chunk = (length*elemSize+31)/32, index*elemSize/32
+	}
+	return concatGeneralizedIndex(gindex, treeDepth(chunks), chunk)
+}
+
+// treeDepth returns the depth of a tree holding the given number of chunks.
+func treeDepth(chunks uint64) uint64 {
+	if chunks == 0 {
+		return 0
+	}
+	return bitLength(chunks - 1)
+}
+
+// concatGeneralizedIndex returns the generalized index of the node at the given index
+// of the level depth below the node at gindex.
+func concatGeneralizedIndex(gindex uint64, depth uint64, index uint64) (uint64, error) {
+	if bitLength(gindex)+depth > 64 {
+		return 0, errors.New("generalized index does not fit in 64 bits")
+	}
+	return gindex<<depth | index, nil
+}
+
+// pathIndex converts an integer path element into an index.
+func pathIndex(elem interface{}) (uint64, bool) {
+	val := reflect.ValueOf(elem)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val.Int() < 0 {
+			return 0, false
+		}
+		return uint64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return val.Uint(), true
+	default:
+		return 0, false
+	}
+}
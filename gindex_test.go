@@ -0,0 +1,102 @@
+package ssz_test
+
+import (
+	"reflect"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type wideList struct {
+	Forks []fork `ssz-max:"1099511627776"`
+}
+
+type wideLists struct {
+	Lists []wideList `ssz-max:"1099511627776"`
+}
+
+func TestGeneralizedIndex(t *testing.T) {
+	typ := reflect.TypeOf(proofItem{})
+	tests := []struct {
+		path []interface{}
+		want uint64
+	}{
+		{path: nil, want: 1},
+		{path: []interface{}{"Slot"}, want: 8},
+		{path: []interface{}{"slot"}, want: 8},
+		{path: []interface{}{"Roots", ssz.LengthPathElement}, want: 19},
+		{path: []interface{}{"Roots", 1}, want: 18*8 + 1},
+		// Four balances are packed into each chunk of a tree of depth 2.
+		{path: []interface{}{"Balances", 4}, want: 20*4 + 1},
+		{path: []interface{}{"Balances", uint64(15)}, want: 20*4 + 3},
+		// Forks are containers of 3 fields, so their trees have depth 2.
+		{path: []interface{}{"Forks", 1, "Epoch"}, want: (22*4+1)*4 + 2},
+		// A capacity of 64 bits fits in a single chunk.
+		{path: []interface{}{"Bits", 63}, want: 24},
+		{path: []interface{}{"Bits", ssz.LengthPathElement}, want: 25},
+		// Pointers are followed.
+		{path: []interface{}{"Nested", "Field2", "Epoch"}, want: (13*4+1)*4 + 2},
+	}
+	for _, tt := range tests {
+		got, err := ssz.GeneralizedIndex(typ, tt.path...)
+		if err != nil {
+			t.Fatalf("Path %v: %v", tt.path, err)
+		}
+		if got != tt.want {
+			t.Errorf("Path %v: expected generalized index %d, received %d", tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestGeneralizedIndex_MatchesProve(t *testing.T) {
+	tests := []struct {
+		path []interface{}
+		leaf [32]byte
+	}{
+		{path: []interface{}{"Slot"}, leaf: [32]byte{9}},
+		{path: []interface{}{"Roots", 2}, leaf: [32]byte{3}},
+		{path: []interface{}{"Roots", ssz.LengthPathElement}, leaf: [32]byte{3}},
+		{path: []interface{}{"Balances", 4}, leaf: [32]byte{5}},
+		{path: []interface{}{"Forks", 1, "Epoch"}, leaf: [32]byte{5}},
+		{path: []interface{}{"Nested", "Field3"}, leaf: [32]byte{32, 33, 34}},
+	}
+	for _, tt := range tests {
+		gindex, err := ssz.GeneralizedIndex(reflect.TypeOf(proofItemExample), tt.path...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaf, _, err := ssz.Prove(proofItemExample, gindex)
+		if err != nil {
+			t.Fatalf("Path %v: %v", tt.path, err)
+		}
+		if leaf != tt.leaf {
+			t.Errorf("Path %v: expected leaf %#x, received %#x", tt.path, tt.leaf, leaf)
+		}
+	}
+}
+
+func TestGeneralizedIndex_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		path []interface{}
+	}{
+		{name: "UnknownField", typ: reflect.TypeOf(proofItem{}), path: []interface{}{"Missing"}},
+		{name: "IndexPastCapacity", typ: reflect.TypeOf(proofItem{}), path: []interface{}{"Roots", 8}},
+		{name: "IndexPastVectorLength", typ: reflect.TypeOf(fork{}), path: []interface{}{"PreviousVersion", 4}},
+		{name: "NegativeIndex", typ: reflect.TypeOf(proofItem{}), path: []interface{}{"Roots", -1}},
+		{name: "ListWithoutCapacity", typ: reflect.TypeOf(nestedItem{}), path: []interface{}{"Field1", 0}},
+		{name: "LengthOfVector", typ: reflect.TypeOf(fork{}), path: []interface{}{"PreviousVersion", ssz.LengthPathElement}},
+		{name: "BelowLength", typ: reflect.TypeOf(proofItem{}), path: []interface{}{"Roots", ssz.LengthPathElement, 0}},
+		{name: "BelowBasicValue", typ: reflect.TypeOf(proofItem{}), path: []interface{}{"Slot", 0}},
+		{name: "IndexIntoStruct", typ: reflect.TypeOf(proofItem{}), path: []interface{}{0}},
+		{name: "Overflow", typ: reflect.TypeOf(wideLists{}), path: []interface{}{"Lists", 0, "Forks", 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ssz.GeneralizedIndex(tt.typ, tt.path...); err == nil {
+				t.Errorf("Expected error for path %v, received nil", tt.path)
+			}
+		})
+	}
+}
@@ -1,15 +1,14 @@
 package ssz
 
 import (
-	"bytes"
-	"encoding/binary"
 	"errors"
-	"fmt"
+	"math"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/karlseguin/ccache"
-	"github.com/minio/highwayhash"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -28,13 +27,44 @@ var (
 	})
 	hashCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "ssz_hash_cache_size",
-		Help: "The number of hashes in the block cache",
+		Help: "The number of hashes in the default hash cache",
 	})
 )
 
-// hashCacheS struct with one queue for looking up by hash.
-type hashCacheS struct {
-	hashCache *ccache.Cache
+// EvictionPolicy decides which roots a full Cache evicts first.
+type EvictionPolicy int
+
+const (
+	// EvictLRU evicts the least recently used roots first.
+	EvictLRU EvictionPolicy = iota
+	// EvictFIFO evicts the least recently added roots first, whether or not they have
+	// been used since.
+	EvictFIFO
+)
+
+// CacheConfig configures a Cache. Zero fields take the defaults of the package cache.
+type CacheConfig struct {
+	// MaxSize is the number of roots the cache holds, 100000 by default.
+	MaxSize int64
+	// TTL is how long a root is kept after being added, an hour by default.
+	TTL time.Duration
+	// Policy decides which roots are evicted once the cache is full.
+	Policy EvictionPolicy
+}
+
+// Cache remembers the roots of values, keyed by their type and content, so that
+// hashing an equal value again does not merkleize it. A Cache is safe for concurrent
+// use. The package has a default cache, which SetCache replaces, and a cache can
+// be used for a single call with the WithCache option:
+//  cache := ssz.NewCache(ssz.CacheConfig{MaxSize: 1000, TTL: time.Minute})
+//  root, err := ssz.HashTreeRootWithOptions(state, ssz.WithCache(cache))
+// Only roots computed with SHA256 are cached.
+type Cache struct {
+	items *ccache.Cache
+	ttl   time.Duration
+	// isDefault is 1 while the cache is the default cache, whose size is the only
+	// one reported as ssz_hash_cache_size. It is accessed atomically.
+	isDefault int32
 }
 
 // root specifies the hash of data in a struct
@@ -43,19 +73,85 @@ type root struct {
 	MerkleRoot []byte
 }
 
-// newHashCache creates a new hash cache for storing/accessing root hashes from
-// memory.
-func newHashCache(maxCacheSize int64) *hashCacheS {
-	return &hashCacheS{
-		hashCache: ccache.New(ccache.Configure().MaxSize(maxCacheSize)),
+// NewCache creates a new hash cache for storing/accessing root hashes from memory.
+func NewCache(config CacheConfig) *Cache {
+	if config.MaxSize <= 0 {
+		config.MaxSize = 100000
+	}
+	if config.TTL <= 0 {
+		config.TTL = time.Hour
+	}
+	conf := ccache.Configure().MaxSize(config.MaxSize)
+	if config.Policy == EvictFIFO {
+		// Roots which are never promoted stay in the order they were added.
+		conf = conf.GetsPerPromote(math.MaxInt32)
+	}
+	return &Cache{
+		items: ccache.New(conf),
+		ttl:   config.TTL,
+	}
+}
+
+// Len returns the number of roots in the cache, including expired roots which have
+// not been evicted yet.
+func (b *Cache) Len() int {
+	return b.items.ItemCount()
+}
+
+// Clear removes all the roots from the cache.
+func (b *Cache) Clear() {
+	b.items.Clear()
+}
+
+var (
+	cacheLock    sync.RWMutex
+	defaultCache = func() *Cache {
+		c := NewCache(CacheConfig{})
+		c.isDefault = 1
+		return c
+	}()
+	useCache = true
+)
+
+// SetCache replaces the cache used by default, which is disabled if c is nil.
+func SetCache(c *Cache) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if defaultCache != nil {
+		atomic.StoreInt32(&defaultCache.isDefault, 0)
+	}
+	size := 0
+	if c != nil {
+		atomic.StoreInt32(&c.isDefault, 1)
+		size = c.Len()
+	}
+	hashCacheSize.Set(float64(size))
+	defaultCache = c
+}
+
+// ToggleCache allows to programmatically enable/disable the hash tree root cache.
+func ToggleCache(enableTreeCache bool) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	useCache = enableTreeCache
+}
+
+// cacheOrDefault returns the cache used when none is given, or nil if caching is
+// disabled.
+func cacheOrDefault() *Cache {
+	cacheLock.RLock()
+	defer cacheLock.RUnlock()
+	if !useCache {
+		return nil
 	}
+	return defaultCache
 }
 
-// RootByEncodedHash fetches Root by the encoded hash of the object. Returns true with a
+// rootByEncodedHash fetches Root by the encoded hash of the object. Returns true with a
 // reference to the root if exists. Otherwise returns false, nil.
-func (b *hashCacheS) RootByEncodedHash(h []byte) (bool, *root, error) {
-	item := b.hashCache.Get(string(h))
-	if item == nil {
+func (b *Cache) rootByEncodedHash(h []byte) (bool, *root, error) {
+	item := b.items.Get(string(h))
+	if item == nil || item.Expired() {
 		hashCacheMiss.Inc()
 		return false, nil, nil
 	}
@@ -68,102 +164,46 @@ func (b *hashCacheS) RootByEncodedHash(h []byte) (bool, *root, error) {
 	return true, hInfo, nil
 }
 
-func (b *hashCacheS) lookup(
+func (b *Cache) lookup(
 	rval reflect.Value,
-	hasher hasher,
-	marshaler marshaler,
+	utils *sszUtils,
 	maxCapacity uint64,
+	c *hashContext,
 ) ([32]byte, error) {
-	cacheKey, err := generateCacheKey(rval, marshaler, maxCapacity)
-	if err != nil {
-		return [32]byte{}, err
-	}
-	// We take the hash of the generated cache key.
-	h, _ := highwayhash.New(make([]byte, 32))
-
-	if _, err := h.Write(cacheKey); err != nil {
-		return [32]byte{}, err
+	hs, ok := cacheKey(rval, utils.typ, maxCapacity)
+	if !ok {
+		return utils.hasher(rval, maxCapacity, c)
 	}
-	hs := h.Sum(nil)
-	exists, fetchedInfo, err := b.RootByEncodedHash(hs)
+	exists, fetchedInfo, err := b.rootByEncodedHash(hs)
 	if err != nil {
 		return [32]byte{}, err
 	}
 	if exists {
 		return toBytes32(fetchedInfo.MerkleRoot), nil
 	}
-	res, err := hasher(rval, maxCapacity)
+	res, err := utils.hasher(rval, maxCapacity, c)
 	if err != nil {
 		return [32]byte{}, err
 	}
-	err = b.AddRoot(hs, res[:])
+	err = b.addRoot(hs, res[:])
 	if err != nil {
 		return [32]byte{}, err
 	}
 	return res, nil
 }
 
-// AddRoot adds an encodedhash of the object as key and a rootHash object to the cache.
+// addRoot adds an encodedhash of the object as key and a rootHash object to the cache.
 // This method also trims the
 // least recently added root info if the cache size has reached the max cache
 // size limit.
-func (b *hashCacheS) AddRoot(h []byte, rootB []byte) error {
+func (b *Cache) addRoot(h []byte, rootB []byte) error {
 	mr := &root{
 		Hash:       h,
 		MerkleRoot: rootB,
 	}
-	b.hashCache.Set(string(h), mr, time.Hour)
-	hashCacheSize.Set(float64(b.hashCache.ItemCount()))
+	b.items.Set(string(h), mr, b.ttl)
+	if atomic.LoadInt32(&b.isDefault) == 1 {
+		hashCacheSize.Set(float64(b.items.ItemCount()))
+	}
 	return nil
 }
-
-func generateCacheKey(v reflect.Value, marshaler marshaler, maxCapacity uint64) ([]byte, error) {
-	encodedLength := make([]byte, 8)
-	encodedCapacity := make([]byte, 8)
-	binary.LittleEndian.PutUint64(encodedCapacity, maxCapacity)
-	var buf []byte
-	var err error
-	if v.Kind() == reflect.Struct {
-		buf, err = generateStructHashKey(v)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		if v.Kind() != reflect.Struct || (v.Kind() == reflect.Ptr && !v.IsNil()) {
-			buf = make([]byte, determineSize(v))
-			if _, err := marshaler(v, buf, 0); err != nil {
-				return nil, err
-			}
-			binary.LittleEndian.PutUint64(encodedLength, uint64(len(buf)))
-		}
-		buf = append(buf, []byte(v.Type().String())...)
-	}
-	lengthMetadata := append(encodedCapacity, encodedLength...)
-	buf = append(buf, lengthMetadata...)
-	return buf, nil
-}
-
-func generateStructHashKey(v reflect.Value) ([]byte, error) {
-	t := v.Type()
-	fields, err := structFields(t)
-	if err != nil {
-		return nil, err
-	}
-	var buf bytes.Buffer
-	buf.WriteString(t.String())
-	for i := 0; i < len(fields); i++ {
-		f := fields[i]
-		buf.WriteString(f.typ.String())
-		buf.WriteString(f.name)
-		if f.typ.Kind() == reflect.Array {
-			buf.WriteString(fmt.Sprintf("%d", f.typ.Len()))
-		}
-		if f.typ.Kind() == reflect.Slice {
-			buf.WriteString(fmt.Sprintf("%d", v.Field(f.index).Len()))
-		}
-		buf.WriteString(fmt.Sprintf("%d", f.capacity))
-		buf.WriteString(fmt.Sprintf("%v", v.Field(f.index).Interface()))
-	}
-	buf.WriteString(string(len(fields)))
-	return buf.Bytes(), nil
-}
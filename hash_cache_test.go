@@ -6,7 +6,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/minio/highwayhash"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 type junkObject struct {
@@ -43,37 +43,27 @@ func generateJunkObject(size uint64) []*junkObject {
 
 func TestCache_byHash(t *testing.T) {
 	byteSl := [][]byte{{0, 0}, {1, 1}}
-	useCache = false
+	ToggleCache(false)
 	mr, err := HashTreeRoot(byteSl)
 	if err != nil {
 		t.Fatal(err)
 	}
-	marshaler, err := makeCompositeSliceMarshaler(reflect.TypeOf(byteSl))
-	if err != nil {
-		t.Fatal(err)
-	}
-	k, err := generateCacheKey(reflect.ValueOf(byteSl), marshaler, 0)
-	if err != nil {
-		t.Fatal(err)
-	}
-	h, _ := highwayhash.New(make([]byte, 32))
-	if _, err := h.Write(k); err != nil {
-		t.Fatal(err)
+	hs, ok := cacheKey(reflect.ValueOf(byteSl), reflect.TypeOf(byteSl), 0)
+	if !ok {
+		t.Fatal("Expected a cache key for a slice of byte slices")
 	}
-	// We take the hash of the generate cache key.
-	hs := h.Sum(nil)
-	exists, _, err := hashCache.RootByEncodedHash(hs)
+	exists, _, err := defaultCache.rootByEncodedHash(hs)
 	if err != nil {
 		t.Fatal(err)
 	}
 	if exists {
 		t.Error("Expected block info not to exist in empty cache")
 	}
-	useCache = true
+	ToggleCache(true)
 	if _, err := HashTreeRoot(byteSl); err != nil {
 		t.Fatal(err)
 	}
-	exists, fetchedInfo, err := hashCache.RootByEncodedHash(hs)
+	exists, fetchedInfo, err := defaultCache.rootByEncodedHash(hs)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -96,8 +86,124 @@ func TestCache_byHash(t *testing.T) {
 	}
 }
 
+func TestCache_UnionsKeyedByValue(t *testing.T) {
+	ToggleCache(true)
+	for _, v := range []uint64{5, 7} {
+		u := Union{Selector: 1, Value: v}
+		ToggleCache(false)
+		want, err := HashTreeRoot(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ToggleCache(true)
+		got, err := HashTreeRoot(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Cached root of union holding %d = %#x, want %#x", v, got, want)
+		}
+	}
+}
+
+func TestCache_WithCache(t *testing.T) {
+	ToggleCache(true)
+	defaultCache.Clear()
+	cache := NewCache(CacheConfig{MaxSize: 10})
+	val := []uint64{1, 2, 3}
+	want, err := HashTreeRootWithOptions(val, WithCache(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if defaultCache.Len() != 0 {
+		t.Errorf("Expected no roots in the default cache, received %d", defaultCache.Len())
+	}
+	for i := 0; i < 2; i++ {
+		got, err := HashTreeRootWithOptions(val, WithCache(cache))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("HashTreeRootWithOptions() = %#x, want %#x", got, want)
+		}
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 root in the cache, received %d", cache.Len())
+	}
+	if defaultCache.Len() != 0 {
+		t.Errorf("Expected no roots in the default cache, received %d", defaultCache.Len())
+	}
+
+	SetCache(cache)
+	defer SetCache(defaultCache)
+	cache.Clear()
+	if _, err := HashTreeRoot(val); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 root in the cache set by SetCache, received %d", cache.Len())
+	}
+}
+
+func TestCache_SizeReportedForDefaultCacheOnly(t *testing.T) {
+	ToggleCache(true)
+	defaultCache.Clear()
+	if _, err := HashTreeRoot([]uint64{1}); err != nil {
+		t.Fatal(err)
+	}
+	if size := testutil.ToFloat64(hashCacheSize); size != 1 {
+		t.Errorf("Reported cache size = %v, want 1", size)
+	}
+	cache := NewCache(CacheConfig{})
+	for i := uint64(0); i < 3; i++ {
+		if _, err := HashTreeRootWithOptions([]uint64{i}, WithCache(cache)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if size := testutil.ToFloat64(hashCacheSize); size != 1 {
+		t.Errorf("Reported cache size = %v after using another cache, want the size of the default cache 1", size)
+	}
+	SetCache(cache)
+	defer SetCache(defaultCache)
+	if size := testutil.ToFloat64(hashCacheSize); size != 3 {
+		t.Errorf("Reported cache size = %v after SetCache, want 3", size)
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	cache := NewCache(CacheConfig{TTL: time.Millisecond})
+	h := []byte("key")
+	if err := cache.addRoot(h, make([]byte, 32)); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _, err := cache.rootByEncodedHash(h); err != nil || !exists {
+		t.Fatalf("Expected root to be cached, received %v, %v", exists, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if exists, _, err := cache.rootByEncodedHash(h); err != nil || exists {
+		t.Errorf("Expected expired root to be missed, received %v, %v", exists, err)
+	}
+}
+
+func TestCache_ToggleConcurrently(t *testing.T) {
+	defer ToggleCache(true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			ToggleCache(i%2 == 0)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if _, err := HashTreeRoot([]uint64{uint64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+}
+
 func BenchmarkHashWithoutCache(b *testing.B) {
-	useCache = false
+	ToggleCache(false)
 	First := generateJunkObject(100)
 	HashTreeRoot(&tree{First: First, Second: First})
 	for n := 0; n < b.N; n++ {
@@ -106,7 +212,7 @@ func BenchmarkHashWithoutCache(b *testing.B) {
 }
 
 func BenchmarkHashWithCache(b *testing.B) {
-	useCache = true
+	ToggleCache(true)
 	First := generateJunkObject(100)
 	type tree struct {
 		First  []*junkObject
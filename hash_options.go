@@ -0,0 +1,46 @@
+package ssz
+
+import "runtime"
+
+// HashOption configures how HashTreeRootWithOptions computes a root.
+type HashOption func(*hashOptions)
+
+type hashOptions struct {
+	hasher   Hasher
+	workers  int
+	cache    *Cache
+	hasCache bool
+}
+
+// WithHasher merkleizes with h instead of the Hasher set by SetHasher.
+func WithHasher(h Hasher) HashOption {
+	return func(o *hashOptions) {
+		o.hasher = h
+	}
+}
+
+// Parallel splits the element roots and the merkleization of large lists and vectors
+// across at most workers goroutines, or GOMAXPROCS goroutines if workers is not
+// positive. The root is the same as the serial one. The Hasher must be safe for
+// concurrent use, as SHA256 is.
+//  root, err := ssz.HashTreeRootWithOptions(state, ssz.Parallel(8))
+//  if err != nil {
+//      return fmt.Errorf("failed to compute root: %v", err)
+//  }
+func Parallel(workers int) HashOption {
+	return func(o *hashOptions) {
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		o.workers = workers
+	}
+}
+
+// WithCache looks roots up in c instead of the cache set by SetCache, or disables
+// caching for the call if c is nil.
+func WithCache(c *Cache) HashOption {
+	return func(o *hashOptions) {
+		o.cache = c
+		o.hasCache = true
+	}
+}
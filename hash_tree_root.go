@@ -1,8 +1,6 @@
 package ssz
 
 import (
-	"bytes"
-	"encoding/binary"
 	"errors"
 	"fmt"
 	"reflect"
@@ -10,13 +8,6 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 )
 
-var useCache = true
-
-// ToggleCache allows to programmatically enable/disable the hash tree root cache.
-func ToggleCache(enableTreeCache bool) {
-	useCache = enableTreeCache
-}
-
 // HashTreeRoot determines the root hash using SSZ's merkleization.
 // Given a struct with the following fields, one can tree hash it as follows:
 //  type exampleStruct struct {
@@ -33,22 +24,43 @@ func ToggleCache(enableTreeCache bool) {
 //      return fmt.Errorf("failed to compute root: %v", err)
 //  }
 func HashTreeRoot(val interface{}) ([32]byte, error) {
+	return HashTreeRootWithOptions(val)
+}
+
+// HashTreeRootWithHasher determines the root hash using SSZ's merkleization with the
+// given Hasher instead of the one set by SetHasher. Types which implement HashRoot
+// are still hashed by their own method.
+func HashTreeRootWithHasher(val interface{}, h Hasher) ([32]byte, error) {
+	return HashTreeRootWithOptions(val, WithHasher(h))
+}
+
+// HashTreeRootWithOptions determines the root hash using SSZ's merkleization as
+// configured by opts, such as Parallel, WithHasher or WithCache.
+func HashTreeRootWithOptions(val interface{}, opts ...HashOption) ([32]byte, error) {
 	if val == nil {
 		return [32]byte{}, errors.New("untyped nil is not supported")
 	}
+	options := hashOptions{hasher: currentHasher()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.hasher == nil {
+		return [32]byte{}, errors.New("nil hasher is not supported")
+	}
 	rval := reflect.ValueOf(val)
 	sszUtils, err := cachedSSZUtils(rval.Type())
 	if err != nil {
 		return [32]byte{}, fmt.Errorf("could not get ssz utils for type: %v: %v", rval.Type(), err)
 	}
-	var output [32]byte
-	if useCache {
-		output, err = hashCache.lookup(rval, sszUtils.hasher, sszUtils.marshaler, 0)
-	} else {
-		output, err = sszUtils.hasher(rval, 0)
+	c := newHashContext(options.hasher)
+	c.workers = options.workers
+	if options.hasCache {
+		c.cache = options.cache
 	}
+	defer c.release()
+	output, err := hashValue(rval, sszUtils, 0, c)
 	if err != nil {
-		return [32]byte{}, fmt.Errorf("could not tree hash type: %v: %v", rval.Type(), err)
+		return [32]byte{}, newHashError(err, rval.Type())
 	}
 	return output, nil
 }
@@ -74,38 +86,52 @@ func HashTreeRootWithCapacity(val interface{}, maxCapacity uint64) ([32]byte, er
 	if err != nil {
 		return [32]byte{}, fmt.Errorf("could not get ssz utils for type: %v: %v", rval.Type(), err)
 	}
-	var output [32]byte
-	if useCache {
-		output, err = hashCache.lookup(rval, sszUtils.hasher, sszUtils.marshaler, maxCapacity)
-	} else {
-		output, err = sszUtils.hasher(rval, maxCapacity)
-	}
+	c := newHashContext(currentHasher())
+	defer c.release()
+	output, err := hashValue(rval, sszUtils, maxCapacity, c)
 	if err != nil {
-		return [32]byte{}, fmt.Errorf("could not tree hash type: %v: %v", rval.Type(), err)
+		return [32]byte{}, newHashError(err, rval.Type())
 	}
 	return output, nil
 }
 
+// hashValue computes the root of val using utils, looking it up in the cache of the
+// context if it has one. The cache only holds SHA-256 roots, and is not used for
+// structs with a RootCache.
+func hashValue(val reflect.Value, utils *sszUtils, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+	if c.cache != nil && c.hasher == SHA256 && !utils.hasRootCache {
+		return c.cache.lookup(val, utils, maxCapacity, c)
+	}
+	return utils.hasher(val, maxCapacity, c)
+}
+
+// makeHasher returns the hasher of typ, classified with kindOf like the proofs, views
+// and schemas which mirror its trees.
 func makeHasher(typ reflect.Type) (hasher, error) {
-	kind := typ.Kind()
+	if typ.Kind() == reflect.Ptr {
+		return makePtrHasher(typ)
+	}
+	kind, ok := kindOf(typ, false)
 	switch {
-	case isBasicType(kind) || isBasicTypeArray(typ, kind):
+	case !ok:
+		return nil, fmt.Errorf("type %v is not hashable", typ)
+	case kind == KindBasic:
 		return makeBasicTypeHasher(typ)
-	case kind == reflect.Slice && isBasicType(typ.Elem().Kind()):
-		return makeBasicSliceHasher(typ)
-	case kind == reflect.Slice && isBasicTypeArray(typ.Elem(), typ.Elem().Kind()):
-		return makeBasicSliceHasher(typ)
-	case kind == reflect.Array && isBasicTypeArray(typ.Elem(), typ.Elem().Kind()):
+	case kind == KindVector && isPacked(typ.Elem()):
+		// Vectors of basic values, uint128 and uint256 ones included, are packed.
+		return makeBasicTypeHasher(typ)
+	case kind == KindVector && isBasicTypeArray(typ.Elem(), typ.Elem().Kind()):
 		return makeBasicArrayHasher(typ)
-	case kind == reflect.Slice && !isBasicType(typ.Elem().Kind()):
-		return makeCompositeSliceHasher(typ)
-	case kind == reflect.Array:
+	case kind == KindVector:
 		return makeCompositeArrayHasher(typ)
-	case kind == reflect.Struct:
+	case kind == KindList && (isPacked(typ.Elem()) || isBasicTypeArray(typ.Elem(), typ.Elem().Kind())):
+		return makeBasicSliceHasher(typ)
+	case kind == KindList:
+		return makeCompositeSliceHasher(typ)
+	case kind == KindContainer:
 		return makeStructHasher(typ)
-	case kind == reflect.Ptr:
-		return makePtrHasher(typ)
 	default:
+		// Unions get their hashers from makeUnionUtils.
 		return nil, fmt.Errorf("type %v is not hashable", typ)
 	}
 }
@@ -115,44 +141,18 @@ func makeBasicTypeHasher(typ reflect.Type) (hasher, error) {
 	if err != nil {
 		return nil, err
 	}
-	hasher := func(val reflect.Value, maxCapacity uint64) ([32]byte, error) {
-		buf := make([]byte, determineSize(val))
-		if _, err = utils.marshaler(val, buf, 0); err != nil {
-			return [32]byte{}, err
-		}
-		chunks, err := pack([][]byte{buf})
-		if err != nil {
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		start := c.mark()
+		if err := c.appendBasic(val, utils); err != nil {
 			return [32]byte{}, err
 		}
-		return bitwiseMerkleize(chunks, 1, false /* has limit */)
+		return c.merkleize(start, 0, false /* has limit */)
 	}
 	return hasher, nil
 }
 
-func bitlistHasher(val reflect.Value, maxCapacity uint64) ([32]byte, error) {
-	limit := (maxCapacity + 255) / 256
-	if val.IsNil() {
-		length := make([]byte, 32)
-		merkleRoot, err := bitwiseMerkleize([][]byte{}, limit, true /* has limit */)
-		if err != nil {
-			return [32]byte{}, err
-		}
-		return mixInLength(merkleRoot, length), nil
-	}
-	bfield := val.Interface().(bitfield.Bitlist)
-	chunks, err := pack([][]byte{bfield.Bytes()})
-	if err != nil {
-		return [32]byte{}, err
-	}
-	buf := new(bytes.Buffer)
-	binary.Write(buf, binary.LittleEndian, bfield.Len())
-	output := make([]byte, 32)
-	copy(output, buf.Bytes())
-	merkleRoot, err := bitwiseMerkleize(chunks, limit, true /* has limit */)
-	if err != nil {
-		return [32]byte{}, err
-	}
-	return mixInLength(merkleRoot, output), nil
+func bitlistHasher(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+	return bitlistRoot(c, val.Interface().(bitfield.Bitlist), maxCapacity)
 }
 
 func makeBasicArrayHasher(typ reflect.Type) (hasher, error) {
@@ -160,23 +160,12 @@ func makeBasicArrayHasher(typ reflect.Type) (hasher, error) {
 	if err != nil {
 		return nil, err
 	}
-	hasher := func(val reflect.Value, maxCapacity uint64) ([32]byte, error) {
-		var leaves [][]byte
-		for i := 0; i < val.Len(); i++ {
-			r, err := utils.hasher(val.Index(i), 0)
-			if err != nil {
-				return [32]byte{}, err
-			}
-			leaves = append(leaves, r[:])
-		}
-		chunks, err := pack(leaves)
-		if err != nil {
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		start := c.mark()
+		if err := c.appendElementRoots(val, utils.hasher); err != nil {
 			return [32]byte{}, err
 		}
-		if val.Len() == 0 {
-			chunks = [][]byte{}
-		}
-		return bitwiseMerkleize(chunks, 1, false /* has limit */)
+		return c.merkleize(start, 0, false /* has limit */)
 	}
 	return hasher, nil
 }
@@ -186,8 +175,10 @@ func makeCompositeArrayHasher(typ reflect.Type) (hasher, error) {
 	if err != nil {
 		return nil, err
 	}
-	hasher := func(val reflect.Value, maxCapacity uint64) ([32]byte, error) {
-		roots := [][]byte{}
+	elemHasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		return hashValue(val, utils, maxCapacity, c)
+	}
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
 		elemSize := uint64(0)
 		if isBasicType(typ.Elem().Kind()) {
 			elemSize = determineFixedSize(val, typ.Elem())
@@ -195,26 +186,11 @@ func makeCompositeArrayHasher(typ reflect.Type) (hasher, error) {
 			elemSize = 32
 		}
 		limit := (uint64(val.Len())*elemSize + 31) / 32
-		for i := 0; i < val.Len(); i++ {
-			var r [32]byte
-			if useCache {
-				r, err = hashCache.lookup(val.Index(i), utils.hasher, utils.marshaler, 0)
-			} else {
-				r, err = utils.hasher(val.Index(i), 0)
-			}
-			if err != nil {
-				return [32]byte{}, err
-			}
-			roots = append(roots, r[:])
-		}
-		chunks, err := pack(roots)
-		if err != nil {
+		start := c.mark()
+		if err := c.appendElementRoots(val, elemHasher); err != nil {
 			return [32]byte{}, err
 		}
-		if val.Len() == 0 {
-			chunks = [][]byte{}
-		}
-		return bitwiseMerkleize(chunks, limit, true /* has limit */)
+		return c.merkleize(start, limit, true /* has limit */)
 	}
 	return hasher, nil
 }
@@ -224,9 +200,10 @@ func makeBasicSliceHasher(typ reflect.Type) (hasher, error) {
 	if err != nil {
 		return nil, err
 	}
-	hasher := func(val reflect.Value, maxCapacity uint64) ([32]byte, error) {
+	packed := isBasicType(typ.Elem().Kind()) || isBasicUintType(typ.Elem())
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
 		elemSize := uint64(0)
-		if isBasicType(typ.Elem().Kind()) {
+		if packed {
 			elemSize = determineFixedSize(val, typ.Elem())
 		} else {
 			elemSize = 32
@@ -235,37 +212,26 @@ func makeBasicSliceHasher(typ reflect.Type) (hasher, error) {
 		if limit == 0 {
 			limit = 1
 		}
-
-		var leaves [][]byte
-		for i := 0; i < val.Len(); i++ {
-			if isBasicType(val.Index(i).Kind()) {
-				innerBufSize := determineSize(val.Index(i))
-				innerBuf := make([]byte, innerBufSize)
-				if _, err = utils.marshaler(val.Index(i), innerBuf, 0); err != nil {
-					return [32]byte{}, err
-				}
-				leaves = append(leaves, innerBuf)
-			} else {
-				r, err := utils.hasher(val.Index(i), 0)
-				if err != nil {
-					return [32]byte{}, err
+		start := c.mark()
+		switch {
+		case packed && val.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+			c.chunks = append(c.chunks, val.Bytes()...)
+		case packed:
+			for i := 0; i < val.Len(); i++ {
+				if err := c.appendBasic(val.Index(i), utils); err != nil {
+					return [32]byte{}, withPath(err, indexSegment(i), 0)
 				}
-				leaves = append(leaves, r[:])
+			}
+		default:
+			if err := c.appendElementRoots(val, utils.hasher); err != nil {
+				return [32]byte{}, err
 			}
 		}
-		chunks, err := pack(leaves)
-		if err != nil {
-			return [32]byte{}, err
-		}
-		buf := new(bytes.Buffer)
-		binary.Write(buf, binary.LittleEndian, uint64(val.Len()))
-		output := make([]byte, 32)
-		copy(output, buf.Bytes())
-		merkleRoot, err := bitwiseMerkleize(chunks, limit, true /* has limit */)
+		merkleRoot, err := c.merkleize(start, limit, true /* has limit */)
 		if err != nil {
 			return [32]byte{}, err
 		}
-		return mixInLength(merkleRoot, output), nil
+		return c.mixIn(merkleRoot, uint64(val.Len())), nil
 	}
 	return hasher, nil
 }
@@ -275,45 +241,23 @@ func makeCompositeSliceHasher(typ reflect.Type) (hasher, error) {
 	if err != nil {
 		return nil, err
 	}
-	hasher := func(val reflect.Value, maxCapacity uint64) ([32]byte, error) {
-		roots := [][]byte{}
-		output := make([]byte, 32)
-		if val.Len() == 0 && maxCapacity == 0 {
-			merkleRoot, err := bitwiseMerkleize([][]byte{}, 0, true /* has limit */)
-			if err != nil {
-				return [32]byte{}, err
-			}
-			itemMerkleize := mixInLength(merkleRoot, output)
-			return itemMerkleize, nil
-		}
-		for i := 0; i < val.Len(); i++ {
-			var r [32]byte
-			if useCache {
-				r, err = hashCache.lookup(val.Index(i), utils.hasher, utils.marshaler, 0)
-			} else {
-				r, err = utils.hasher(val.Index(i), 0)
-			}
-			if err != nil {
-				return [32]byte{}, err
-			}
-			roots = append(roots, r[:])
-		}
-		chunks, err := pack(roots)
-		if err != nil {
+	elemHasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		return hashValue(val, utils, maxCapacity, c)
+	}
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		start := c.mark()
+		if err := c.appendElementRoots(val, elemHasher); err != nil {
 			return [32]byte{}, err
 		}
-		buf := new(bytes.Buffer)
-		binary.Write(buf, binary.LittleEndian, uint64(val.Len()))
-		copy(output, buf.Bytes())
 		objLen := maxCapacity
 		if maxCapacity == 0 {
 			objLen = uint64(val.Len())
 		}
-		merkleRoot, err := bitwiseMerkleize(chunks, objLen, true /* has limit */)
+		merkleRoot, err := c.merkleize(start, objLen, true /* has limit */)
 		if err != nil {
 			return [32]byte{}, err
 		}
-		return mixInLength(merkleRoot, output), nil
+		return c.mixIn(merkleRoot, uint64(val.Len())), nil
 	}
 	return hasher, nil
 }
@@ -323,50 +267,56 @@ func makeStructHasher(typ reflect.Type) (hasher, error) {
 	if err != nil {
 		return nil, err
 	}
+	if index, ok := rootCacheIndex(typ); ok {
+		return makeRootCacheHasher(fields, index)
+	}
 	return makeFieldsHasher(fields)
 }
 
 func makeFieldsHasher(fields []field) (hasher, error) {
-	hasher := func(val reflect.Value, maxCapacity uint64) ([32]byte, error) {
-		roots := [][]byte{}
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		start := c.mark()
 		for _, f := range fields {
-			var r [32]byte
-			var err error
-			if _, ok := val.Field(f.index).Interface().(bitfield.Bitlist); ok {
-				r, err = bitlistHasher(val.Field(f.index), f.capacity)
-				roots = append(roots, r[:])
-				continue
-			}
-			if useCache {
-				r, err = hashCache.lookup(
-					val.Field(f.index),
-					f.sszUtils.hasher,
-					f.sszUtils.marshaler,
-					f.capacity,
-				)
-			} else {
-				r, err = f.sszUtils.hasher(val.Field(f.index), f.capacity)
-			}
+			r, err := hashField(val, f, c)
 			if err != nil {
-				return [32]byte{}, fmt.Errorf("failed to hash field %s of struct: %v", f.name, err)
+				return [32]byte{}, err
 			}
-			roots = append(roots, r[:])
+			c.appendRoot(r)
 		}
-		return bitwiseMerkleize(roots, uint64(len(fields)), true /* has limit */)
+		return c.merkleize(start, uint64(len(fields)), true /* has limit */)
 	}
 	return hasher, nil
 }
 
+// hashField computes the root of the field f of the struct val.
+func hashField(val reflect.Value, f field, c *hashContext) ([32]byte, error) {
+	var r [32]byte
+	var err error
+	switch fieldVal := val.Field(f.index); fieldVal.Type() {
+	case bitlistType:
+		r, err = bitlistHasher(fieldVal, f.capacity, c)
+	case bigIntType:
+		// Big integers fit in a single chunk, so there is nothing worth caching.
+		r, err = f.sszUtils.hasher(fieldVal, 0, c)
+	default:
+		r, err = hashValue(fieldVal, f.sszUtils, f.capacity, c)
+	}
+	if err != nil {
+		return [32]byte{}, withPath(err, fieldSegment(f.name), 0)
+	}
+	return r, nil
+}
+
 func makePtrHasher(typ reflect.Type) (hasher, error) {
 	elemSSZUtils, err := cachedSSZUtilsNoAcquireLock(typ.Elem())
 	if err != nil {
 		return nil, err
 	}
-	hasher := func(val reflect.Value, maxCapacity uint64) ([32]byte, error) {
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
 		if val.IsNil() {
 			return [32]byte{}, nil
 		}
-		return elemSSZUtils.hasher(val.Elem(), maxCapacity)
+		return elemSSZUtils.hasher(val.Elem(), maxCapacity, c)
 	}
 	return hasher, nil
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func init() {
-	useCache = true
+	ToggleCache(true)
 }
 
 type fork struct {
@@ -26,7 +26,7 @@ type nilItem struct {
 }
 
 func TestNilPointerHashTreeRoot(t *testing.T) {
-	useCache = true
+	ToggleCache(true)
 	i := &nilItem{
 		Field1: []*fork{nil},
 		Field2: 10,
@@ -37,7 +37,7 @@ func TestNilPointerHashTreeRoot(t *testing.T) {
 }
 
 func TestHashTreeRoot(t *testing.T) {
-	useCache = false
+	ToggleCache(false)
 	var currentVersion [4]byte
 	var previousVersion [4]byte
 	prev, err := hex.DecodeString("9f41bd5b")
@@ -69,7 +69,7 @@ func TestHashTreeRoot(t *testing.T) {
 }
 
 func TestHashTreeRootWithCapacity_FailsWithNonSliceType(t *testing.T) {
-	useCache = false
+	ToggleCache(false)
 	forkItem := fork{
 		Epoch: 11971467576204192310,
 	}
@@ -77,11 +77,11 @@ func TestHashTreeRootWithCapacity_FailsWithNonSliceType(t *testing.T) {
 	if _, err := HashTreeRootWithCapacity(forkItem, capacity); err == nil {
 		t.Error("Expected hash tree root to fail with non-slice type")
 	}
-	useCache = true
+	ToggleCache(true)
 }
 
 func TestHashTreeRootWithCapacity_HashesCorrectly(t *testing.T) {
-	useCache = false
+	ToggleCache(false)
 	capacity := uint64(1099511627776)
 	balances := make([]uint64, 512)
 	for i := 0; i < len(balances); i++ {
@@ -100,12 +100,12 @@ func TestHashTreeRootWithCapacity_HashesCorrectly(t *testing.T) {
 	if !bytes.Equal(root[:], want) {
 		t.Errorf("Mismatched roots, wanted %#x == %#x", root, want)
 	}
-	useCache = true
+	ToggleCache(true)
 }
 
 // Regression test for https://github.com/prysmaticlabs/go-ssz/issues/46.
 func TestHashTreeRoot_EncodeSliceLengthCorrectly(t *testing.T) {
-	useCache = false
+	ToggleCache(false)
 	acct := accountBalances{
 		Balances: make([]uint64, 512),
 	}
@@ -125,5 +125,5 @@ func TestHashTreeRoot_EncodeSliceLengthCorrectly(t *testing.T) {
 	if !bytes.Equal(root[:], want) {
 		t.Errorf("Mismatched roots, wanted %#x == %#x", root, want)
 	}
-	useCache = true
+	ToggleCache(true)
 }
@@ -0,0 +1,128 @@
+package ssz
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/minio/sha256-simd"
+)
+
+// Hasher is a hash function used to merkleize values. The specification requires
+// SHA-256, which SHA256 provides, but other hash functions can be plugged in for
+// uses of SSZ outside of Ethereum, such as hashes which are cheap to prove in zero
+// knowledge circuits:
+//  h := ssz.NewHasher(poseidon.Sum)
+//  root, err := ssz.HashTreeRootWithHasher(block, h)
+//  if err != nil {
+//      return fmt.Errorf("failed to compute root: %v", err)
+//  }
+type Hasher interface {
+	// Hash returns the digest of data.
+	Hash(data []byte) [32]byte
+	// HashPairs hashes every consecutive 64-byte pair of chunks in pairs, and writes
+	// their 32-byte digests, in order, to dst, which holds len(pairs)/2 bytes. dst
+	// may be the start of pairs, so a pair must be read before its digest is written.
+	// Implementations can hash the pairs in batches.
+	HashPairs(dst []byte, pairs []byte)
+}
+
+// SHA256 is the SHA-256 Hasher required by the specification, and the default one.
+var SHA256 Hasher = sha256Hasher{}
+
+var (
+	hasherLock sync.RWMutex
+	// defaultHasher is the Hasher used by calls which are not given one. It is read
+	// through currentHasher.
+	defaultHasher = SHA256
+)
+
+// SetHasher sets the Hasher used by HashTreeRoot, SigningRoot and the other functions
+// which compute roots and proofs without being given a Hasher. A nil Hasher restores
+// SHA256.
+func SetHasher(h Hasher) {
+	if h == nil {
+		h = SHA256
+	}
+	hasherLock.Lock()
+	defer hasherLock.Unlock()
+	defaultHasher = h
+}
+
+// currentHasher returns the Hasher used by calls which are not given one.
+func currentHasher() Hasher {
+	hasherLock.RLock()
+	defer hasherLock.RUnlock()
+	return defaultHasher
+}
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Hash(data []byte) [32]byte {
+	return sha256.Sum256(data)
+}
+
+func (sha256Hasher) HashPairs(dst []byte, pairs []byte) {
+	for i := 0; i+64 <= len(pairs); i += 64 {
+		digest := sha256.Sum256(pairs[i : i+64])
+		copy(dst[i/2:], digest[:])
+	}
+}
+
+// NewHasher returns a Hasher which computes every digest with hash, one pair of
+// chunks at a time.
+func NewHasher(hash func(data []byte) [32]byte) Hasher {
+	return &funcHasher{hash: hash}
+}
+
+type funcHasher struct {
+	hash func(data []byte) [32]byte
+}
+
+func (f *funcHasher) Hash(data []byte) [32]byte {
+	return f.hash(data)
+}
+
+func (f *funcHasher) HashPairs(dst []byte, pairs []byte) {
+	for i := 0; i+64 <= len(pairs); i += 64 {
+		digest := f.hash(pairs[i : i+64])
+		copy(dst[i/2:], digest[:])
+	}
+}
+
+// zeroHashDepth is the number of levels of zero hashes computed for each Hasher,
+// enough for any tree addressed by a 64-bit generalized index.
+const zeroHashDepth = 100
+
+// zeroHashesByHasher holds the zero hashes of every comparable Hasher other than SHA256.
+var zeroHashesByHasher sync.Map
+
+// zeroHashesOf returns the roots of trees of zero chunks under h, indexed by depth.
+func zeroHashesOf(h Hasher) [][32]byte {
+	if h == SHA256 {
+		return zeroHashes
+	}
+	if !reflect.TypeOf(h).Comparable() {
+		return computeZeroHashes(h)
+	}
+	if cached, ok := zeroHashesByHasher.Load(h); ok {
+		return cached.([][32]byte)
+	}
+	cached, _ := zeroHashesByHasher.LoadOrStore(h, computeZeroHashes(h))
+	return cached.([][32]byte)
+}
+
+func computeZeroHashes(h Hasher) [][32]byte {
+	hashes := make([][32]byte, zeroHashDepth)
+	for i := 1; i < zeroHashDepth; i++ {
+		hashes[i] = hash2(h, hashes[i-1][:], hashes[i-1][:])
+	}
+	return hashes
+}
+
+// hash2 hashes two slices together.
+func hash2(h Hasher, x, y []byte) [32]byte {
+	buf := make([]byte, 0, len(x)+len(y))
+	buf = append(buf, x...)
+	buf = append(buf, y...)
+	return h.Hash(buf)
+}
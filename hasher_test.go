@@ -0,0 +1,152 @@
+package ssz_test
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type hasherItem struct {
+	Roots [][32]byte `ssz-max:"4"`
+}
+
+// sliceHasher is a Hasher which is not comparable, so its zero hashes cannot be cached.
+type sliceHasher struct {
+	prefix []byte
+}
+
+func (s sliceHasher) Hash(data []byte) [32]byte {
+	return sha512.Sum512_256(append(append([]byte{}, s.prefix...), data...))
+}
+
+func (s sliceHasher) HashPairs(dst []byte, pairs []byte) {
+	for i := 0; i+64 <= len(pairs); i += 64 {
+		digest := s.Hash(pairs[i : i+64])
+		copy(dst[i/2:], digest[:])
+	}
+}
+
+func TestHashTreeRootWithHasher_SHA256MatchesDefault(t *testing.T) {
+	values := []interface{}{
+		proofItemExample,
+		hasherItem{Roots: [][32]byte{{1}, {2}, {3}}},
+		[]uint64{1, 2, 3},
+		[3]fork{},
+	}
+	wrapped := ssz.NewHasher(sha256.Sum256)
+	for _, cache := range []bool{true, false} {
+		ssz.ToggleCache(cache)
+		for _, val := range values {
+			want, err := ssz.HashTreeRoot(val)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, h := range []ssz.Hasher{ssz.SHA256, wrapped} {
+				got, err := ssz.HashTreeRootWithHasher(val, h)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("Root of %T with cache %v = %#x, want %#x", val, cache, got, want)
+				}
+			}
+		}
+	}
+	ssz.ToggleCache(true)
+}
+
+func TestHashTreeRootWithHasher_ZeroHashesPerHasher(t *testing.T) {
+	alt := ssz.NewHasher(sha512.Sum512_256)
+	hashPair := func(a, b [32]byte) [32]byte {
+		return sha512.Sum512_256(append(a[:], b[:]...))
+	}
+	// An empty list of at most four roots pads to a tree of depth two, and the
+	// container of that single field is the root of the list.
+	zero1 := hashPair([32]byte{}, [32]byte{})
+	zero2 := hashPair(zero1, zero1)
+	want := hashPair(zero2, [32]byte{})
+
+	got, err := ssz.HashTreeRootWithHasher(hasherItem{}, alt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("HashTreeRootWithHasher() = %#x, want %#x", got, want)
+	}
+	sha256Root, err := ssz.HashTreeRoot(hasherItem{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == sha256Root {
+		t.Error("Expected roots to differ between hash functions")
+	}
+}
+
+func TestHashTreeRootWithHasher_NotComparable(t *testing.T) {
+	h := sliceHasher{prefix: []byte("domain")}
+	first, err := ssz.HashTreeRootWithHasher(proofItemExample, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ssz.HashTreeRootWithHasher(proofItemExample, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("Expected deterministic roots, received %#x and %#x", first, second)
+	}
+}
+
+func TestSetHasher(t *testing.T) {
+	defer ssz.SetHasher(nil)
+	val := hasherItem{Roots: [][32]byte{{1}}}
+	want, err := ssz.HashTreeRoot(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alt := ssz.NewHasher(sha512.Sum512_256)
+	altRoot, err := ssz.HashTreeRootWithHasher(val, alt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ssz.SetHasher(alt)
+	got, err := ssz.HashTreeRoot(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != altRoot {
+		t.Errorf("HashTreeRoot() after SetHasher = %#x, want %#x", got, altRoot)
+	}
+	ssz.SetHasher(nil)
+	if got, err = ssz.HashTreeRoot(val); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("HashTreeRoot() after resetting hasher = %#x, want %#x", got, want)
+	}
+}
+
+func TestSetHasher_Concurrently(t *testing.T) {
+	defer ssz.SetHasher(nil)
+	alt := ssz.NewHasher(sha512.Sum512_256)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			if i%2 == 0 {
+				ssz.SetHasher(alt)
+			} else {
+				ssz.SetHasher(nil)
+			}
+		}
+	}()
+	val := hasherItem{Roots: [][32]byte{{1}}}
+	for i := 0; i < 100; i++ {
+		if _, err := ssz.HashTreeRoot(val); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+}
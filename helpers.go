@@ -2,11 +2,8 @@ package ssz
 
 import (
 	"bytes"
-	"fmt"
-	"math"
+	"math/bits"
 	"reflect"
-
-	"github.com/minio/sha256-simd"
 )
 
 var (
@@ -14,17 +11,10 @@ var (
 	BytesPerChunk = 32
 	// BytesPerLengthOffset defines a constant for off-setting serialized chunks.
 	BytesPerLengthOffset = uint64(4)
-	zeroHashes           = make([][32]byte, 100)
+	// zeroHashes are the SHA-256 roots of trees of zero chunks, indexed by depth.
+	zeroHashes = computeZeroHashes(SHA256)
 )
 
-func init() {
-	for i := 1; i < 100; i++ {
-		leaf := append(zeroHashes[i-1][:], zeroHashes[i-1][:]...)
-		result := hash(leaf)
-		copy(zeroHashes[i][:], result[:])
-	}
-}
-
 // Given ordered objects of the same basic type, serialize them, pack them into BYTES_PER_CHUNK-byte
 // chunks, right-pad the last chunk with zero bytes, and return the chunks.
 // Basic types are either bool, or uintN where N = {8, 16, 32, 64, 128, 256}.
@@ -75,86 +65,9 @@ func pack(serializedItems [][]byte) ([][]byte, error) {
 	return chunks, nil
 }
 
-// Given ordered BYTES_PER_CHUNK-byte chunks, if necessary utilize zero chunks so that the
-// number of chunks is a power of two, Merkleize the chunks, and return the root.
-// Note that merkleize on a single chunk is simply that chunk, i.e. the identity
-// when the number of chunks is one.
-func bitwiseMerkleize(chunks [][]byte, limit uint64, hasLimit bool) ([32]byte, error) {
-	padding := limit
-	if !hasLimit {
-		padding = uint64(len(chunks))
-	}
-	count := uint64(len(chunks))
-
-	if count > padding {
-		return [32]byte{}, fmt.Errorf("chunk count = %d cannot be greater than padding = %d", count, padding)
-	}
-	if padding == 0 {
-		return zeroHashes[0], nil
-	}
-
-	depth := uint64(bitLength(0))
-	if bitLength(count-1) > depth {
-		depth = bitLength(count - 1)
-	}
-	maxDepth := bitLength(padding - 1)
-	layers := make([][]byte, maxDepth+1)
-
-	for idx, chunk := range chunks {
-		mergeChunks(layers, chunk, uint64(idx), count, depth)
-	}
-
-	if 1<<depth != count {
-		mergeChunks(layers, zeroHashes[0][:], count, count, depth)
-	}
-
-	for i := depth; i < maxDepth; i++ {
-		res := hash2(layers[i], zeroHashes[i][:])
-		layers[i+1] = res[:]
-	}
-
-	return toBytes32(layers[maxDepth]), nil
-}
-
-func mergeChunks(layers [][]byte, currentRoot []byte, i, count, depth uint64) {
-	j := uint64(0)
-	for {
-		if i&(1<<j) == 0 {
-			if i == count && j < depth {
-				res := hash2(currentRoot[:], zeroHashes[j][:])
-				currentRoot = res[:]
-			} else {
-				break
-			}
-		} else {
-			res := hash2(layers[j], currentRoot[:])
-			currentRoot = res[:]
-		}
-		j++
-	}
-	layers[j] = currentRoot[:]
-}
-
+// bitLength returns the number of bits needed to represent n.
 func bitLength(n uint64) uint64 {
-	if n == 0 {
-		return 0
-	}
-	return uint64(math.Log2(float64(n))) + 1
-}
-
-// Given a Merkle root root and a length length ("uint256" little-endian serialization)
-// return hash(root + length).
-func mixInLength(root [32]byte, length []byte) [32]byte {
-	var hash [32]byte
-	h := sha256.New()
-	h.Write(root[:])
-	h.Write(length)
-	// The hash interface never returns an error, for that reason
-	// we are not handling the error below. For reference, it is
-	// stated here https://golang.org/pkg/hash/#Hash
-	// #nosec G104
-	h.Sum(hash[:0])
-	return hash
+	return uint64(bits.Len64(n))
 }
 
 // Instantiates a reflect value which may not have a concrete type to have a concrete type
@@ -183,22 +96,3 @@ func toBytes32(x []byte) [32]byte {
 	copy(y[:], x)
 	return y
 }
-
-// hash defines a function that returns the sha256 hash of the data passed in.
-func hash(data []byte) [32]byte {
-	return sha256.Sum256(data)
-}
-
-// hash2 hashes two slices together
-func hash2(x, y []byte) [32]byte {
-	var hash [32]byte
-	h := sha256.New()
-	h.Write(x)
-	h.Write(y)
-	// The hash interface never returns an error, for that reason
-	// we are not handling the error below. For reference, it is
-	// stated here https://golang.org/pkg/hash/#Hash
-	// #nosec G104
-	h.Sum(hash[:0])
-	return hash
-}
@@ -1,6 +1,7 @@
 package ssz
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -75,7 +76,9 @@ func TestPack_OK(t *testing.T) {
 
 func TestMerkleize_Identity(t *testing.T) {
 	want := make([]byte, BytesPerChunk)
-	output, err := bitwiseMerkleize([][]byte{}, 0, true /* has limit */)
+	c := newHashContext(SHA256)
+	defer c.release()
+	output, err := c.merkleize(c.mark(), 0, true /* has limit */)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -86,8 +89,8 @@ func TestMerkleize_Identity(t *testing.T) {
 
 func TestMerkleize_OK(t *testing.T) {
 	chunk := make([]byte, BytesPerChunk)
-	secondLayerRoot := hash(append(chunk, chunk...))
-	thirdLayerRoot := hash(append(secondLayerRoot[:], secondLayerRoot[:]...))
+	secondLayerRoot := SHA256.Hash(append(chunk, chunk...))
+	thirdLayerRoot := SHA256.Hash(append(secondLayerRoot[:], secondLayerRoot[:]...))
 	tests := []struct {
 		name   string
 		input  [][]byte
@@ -96,7 +99,7 @@ func TestMerkleize_OK(t *testing.T) {
 		{
 			name:   "two elements should return the hash of their concatenation",
 			input:  [][]byte{make([]byte, BytesPerChunk), make([]byte, BytesPerChunk)},
-			output: hash(make([]byte, BytesPerChunk*2)),
+			output: SHA256.Hash(make([]byte, BytesPerChunk*2)),
 		},
 		{
 			name:   "four chunks should return the Merkle root of a three layer trie",
@@ -111,7 +114,13 @@ func TestMerkleize_OK(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := bitwiseMerkleize(tt.input, 1, false /* has limit */)
+			c := newHashContext(SHA256)
+			defer c.release()
+			start := c.mark()
+			for _, chunk := range tt.input {
+				c.chunks = append(c.chunks, chunk...)
+			}
+			got, err := c.merkleize(start, 1, false /* has limit */)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -121,6 +130,29 @@ func TestMerkleize_OK(t *testing.T) {
 		})
 	}
 }
+
+func TestBitLength(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want uint64
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 2},
+		{255, 8},
+		{256, 9},
+		{1<<53 + 1, 54},
+		{1<<60 - 1, 60},
+		{1 << 63, 64},
+		{math.MaxUint64, 64},
+	}
+	for _, tt := range tests {
+		if got := bitLength(tt.n); got != tt.want {
+			t.Errorf("bitLength(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
 func BenchmarkPack(b *testing.B) {
 	input := [][]byte{make([]byte, BytesPerChunk*8000)}
 	for n := 0; n < b.N; n++ {
@@ -131,11 +163,15 @@ func BenchmarkPack(b *testing.B) {
 }
 
 func BenchmarkMerkleize(b *testing.B) {
-	input := make([][]byte, 8000)
-	for i := 0; i < len(input); i++ {
-		input[i] = make([]byte, BytesPerChunk)
-	}
+	input := make([]byte, 8000*BytesPerChunk)
+	c := newHashContext(SHA256)
+	defer c.release()
+	b.ReportAllocs()
 	for n := 0; n < b.N; n++ {
-		bitwiseMerkleize(input, 1, false /* has limit */)
+		start := c.mark()
+		c.chunks = append(c.chunks, input...)
+		if _, err := c.merkleize(start, 1, false /* has limit */); err != nil {
+			b.Fatal(err)
+		}
 	}
 }
@@ -0,0 +1,114 @@
+package ssz
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Marshaler is the interface implemented by types that can marshal themselves
+// into valid SSZ, such as those generated by cmd/sszgen. MarshalSSZTo appends the
+// encoding to buf and SizeSSZ returns its length in bytes.
+type Marshaler interface {
+	MarshalSSZTo(buf []byte) ([]byte, error)
+	SizeSSZ() int
+}
+
+// Unmarshaler is the interface implemented by types that can unmarshal an SSZ
+// encoding of themselves. The input holds exactly the encoding of the value.
+type Unmarshaler interface {
+	UnmarshalSSZ(buf []byte) error
+}
+
+// HashRoot is the interface implemented by types that can compute their own
+// tree hash root.
+type HashRoot interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+var (
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	hashRootType    = reflect.TypeOf((*HashRoot)(nil)).Elem()
+)
+
+// implements reports whether values of typ, or pointers to them, implement iface.
+// Pointer types are left to makePtrMarshaler and friends, which defer to the
+// utils of their element type.
+func implements(typ reflect.Type, iface reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		return false
+	}
+	return typ.Implements(iface) || reflect.PtrTo(typ).Implements(iface)
+}
+
+// interfaceOf returns the value, or a pointer to it, as an implementation of
+// iface. Values which cannot be addressed are copied so pointer receivers can be
+// used, such as when calling Marshal on a struct value.
+func interfaceOf(val reflect.Value, iface reflect.Type) interface{} {
+	if val.Type().Implements(iface) {
+		return val.Interface()
+	}
+	if val.CanAddr() {
+		return val.Addr().Interface()
+	}
+	ptr := reflect.New(val.Type())
+	ptr.Elem().Set(val)
+	return ptr.Interface()
+}
+
+// customSize returns the size reported by SizeSSZ if val implements Marshaler.
+func customSize(val reflect.Value) (uint64, bool) {
+	if !implements(val.Type(), marshalerType) {
+		return 0, false
+	}
+	return uint64(interfaceOf(val, marshalerType).(Marshaler).SizeSSZ()), true
+}
+
+func makeCustomMarshaler(typ reflect.Type) (marshaler, error) {
+	marshaler := func(val reflect.Value, buf []byte, startOffset uint64) (uint64, error) {
+		m := interfaceOf(val, marshalerType).(Marshaler)
+		// The buffer has been pre-allocated from SizeSSZ, so appending to an empty
+		// slice at the start offset writes the encoding in place.
+		out, err := m.MarshalSSZTo(buf[startOffset:startOffset])
+		if err != nil {
+			return 0, err
+		}
+		if uint64(len(out)) > uint64(len(buf))-startOffset {
+			return 0, fmt.Errorf("type %v marshaled %d bytes, more than its size of %d", typ, len(out), m.SizeSSZ())
+		}
+		copy(buf[startOffset:], out)
+		return startOffset + uint64(len(out)), nil
+	}
+	return marshaler, nil
+}
+
+func makeCustomUnmarshaler(typ reflect.Type) (unmarshaler, error) {
+	unmarshaler := func(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
+		if !val.CanAddr() && !typ.Implements(unmarshalerType) {
+			return 0, fmt.Errorf("cannot unmarshal into unaddressable value of type %v", typ)
+		}
+		endOffset := uint64(len(input))
+		// Fixed-size values may be handed the remainder of their parent's input, so we
+		// only consume as many bytes as their encoding takes up.
+		if !isVariableSizeType(typ) {
+			endOffset = startOffset + determineFixedSize(reflect.New(typ).Elem(), typ)
+		}
+		if startOffset > endOffset || endOffset > uint64(len(input)) {
+			return 0, fmt.Errorf("input of %d bytes is too short for type %v", len(input), typ)
+		}
+		u := interfaceOf(val, unmarshalerType).(Unmarshaler)
+		if err := u.UnmarshalSSZ(input[startOffset:endOffset]); err != nil {
+			return 0, err
+		}
+		return endOffset, nil
+	}
+	return unmarshaler, nil
+}
+
+func makeCustomHasher(typ reflect.Type) (hasher, error) {
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		// Types which hash themselves always use their own hash function.
+		return interfaceOf(val, hashRootType).(HashRoot).HashTreeRoot()
+	}
+	return hasher, nil
+}
@@ -0,0 +1,149 @@
+package ssz_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+// bigEndianUint encodes itself in big-endian order, so tests can tell whether
+// its methods were used over the reflective little-endian encoding.
+type bigEndianUint struct {
+	Val uint64
+}
+
+func (b *bigEndianUint) MarshalSSZTo(buf []byte) ([]byte, error) {
+	out := make([]byte, 8)
+	binary.BigEndian.PutUint64(out, b.Val)
+	return append(buf, out...), nil
+}
+
+func (b *bigEndianUint) SizeSSZ() int {
+	return 8
+}
+
+func (b *bigEndianUint) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != 8 {
+		return errors.New("expected 8 bytes")
+	}
+	b.Val = binary.BigEndian.Uint64(buf)
+	return nil
+}
+
+func (b *bigEndianUint) HashTreeRoot() ([32]byte, error) {
+	return [32]byte{byte(b.Val), 0xff}, nil
+}
+
+// reversedBytes is a variable-size type which encodes its data back to front.
+type reversedBytes struct {
+	Data []byte
+}
+
+func (r reversedBytes) MarshalSSZTo(buf []byte) ([]byte, error) {
+	for i := len(r.Data) - 1; i >= 0; i-- {
+		buf = append(buf, r.Data[i])
+	}
+	return buf, nil
+}
+
+func (r reversedBytes) SizeSSZ() int {
+	return len(r.Data)
+}
+
+func (r *reversedBytes) UnmarshalSSZ(buf []byte) error {
+	r.Data = make([]byte, len(buf))
+	for i := range buf {
+		r.Data[len(buf)-1-i] = buf[i]
+	}
+	return nil
+}
+
+type customContainer struct {
+	Fixed    bigEndianUint
+	Variable reversedBytes
+	List     []bigEndianUint `ssz-max:"4"`
+	Nested   []reversedBytes `ssz-max:"4"`
+}
+
+func TestMarshal_UsesMarshaler(t *testing.T) {
+	val := customContainer{
+		Fixed:    bigEndianUint{Val: 1},
+		Variable: reversedBytes{Data: []byte{1, 2}},
+		List:     []bigEndianUint{{Val: 2}},
+		Nested:   []reversedBytes{{Data: []byte{3, 4}}},
+	}
+	want := []byte{
+		0, 0, 0, 0, 0, 0, 0, 1, // Fixed
+		20, 0, 0, 0, // Variable offset
+		22, 0, 0, 0, // List offset
+		30, 0, 0, 0, // Nested offset
+		2, 1, // Variable
+		0, 0, 0, 0, 0, 0, 0, 2, // List
+		4, 0, 0, 0, 4, 3, // Nested
+	}
+	encoded, err := ssz.Marshal(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Expected %v, received %v", want, encoded)
+	}
+	var decoded customContainer
+	if err := ssz.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(val, decoded) {
+		t.Errorf("Expected %v, received %v", val, decoded)
+	}
+}
+
+func TestMarshal_UsesMarshalerOfTopLevelValue(t *testing.T) {
+	encoded, err := ssz.Marshal(bigEndianUint{Val: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{0, 0, 0, 0, 0, 0, 0, 5}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Expected %v, received %v", want, encoded)
+	}
+	var decoded bigEndianUint
+	if err := ssz.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Val != 5 {
+		t.Errorf("Expected 5, received %d", decoded.Val)
+	}
+}
+
+func TestUnmarshal_UnmarshalerError(t *testing.T) {
+	var decoded bigEndianUint
+	if err := ssz.Unmarshal([]byte{1, 2, 3}, &decoded); err == nil {
+		t.Error("Expected error unmarshaling a short input, received nil")
+	}
+}
+
+func TestHashTreeRoot_UsesHashRoot(t *testing.T) {
+	root, err := ssz.HashTreeRoot(bigEndianUint{Val: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [32]byte{7, 0xff}
+	if root != want {
+		t.Errorf("Expected %#x, received %#x", want, root)
+	}
+	// A container with a single field has the root of that field.
+	wrapped := struct {
+		Field bigEndianUint
+	}{Field: bigEndianUint{Val: 7}}
+	root, err = ssz.HashTreeRoot(wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("Expected %#x, received %#x", want, root)
+	}
+}
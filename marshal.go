@@ -53,10 +53,10 @@ func Marshal(val interface{}) ([]byte, error) {
 	buf := make([]byte, determineSize(rval))
 	sszUtils, err := cachedSSZUtils(rval.Type())
 	if err != nil {
-		return nil, fmt.Errorf("could not initialize marshaler for type: %v", rval.Type())
+		return nil, fmt.Errorf("could not initialize marshaler for type: %v, %v", rval.Type(), err)
 	}
 	if _, err = sszUtils.marshaler(rval, buf, 0 /* start offset */); err != nil {
-		return nil, fmt.Errorf("failed to marshal for type: %v", rval.Type())
+		return nil, newEncodeError(err, rval.Type())
 	}
 	return buf, nil
 }
@@ -158,11 +158,12 @@ func makeBasicSliceMarshaler(typ reflect.Type) (marshaler, error) {
 
 	marshaler := func(val reflect.Value, buf []byte, startOffset uint64) (uint64, error) {
 		index := startOffset
-		var err error
 		for i := 0; i < val.Len(); i++ {
+			elemIndex := index
+			var err error
 			index, err = elemSSZUtils.marshaler(val.Index(i), buf, index)
 			if err != nil {
-				return 0, err
+				return 0, withPath(err, indexSegment(i), elemIndex-startOffset)
 			}
 		}
 		return index, nil
@@ -183,9 +184,10 @@ func makeCompositeSliceMarshaler(typ reflect.Type) (marshaler, error) {
 			for i := 0; i < val.Len(); i++ {
 				// If each element is not variable size, we simply encode sequentially and write
 				// into the buffer at the last index we wrote at.
+				elemIndex := index
 				index, err = elemSSZUtils.marshaler(val.Index(i), buf, index)
 				if err != nil {
-					return 0, err
+					return 0, withPath(err, indexSegment(i), elemIndex-startOffset)
 				}
 			}
 		} else {
@@ -197,7 +199,7 @@ func makeCompositeSliceMarshaler(typ reflect.Type) (marshaler, error) {
 			for i := 0; i < val.Len(); i++ {
 				nextOffsetIndex, err = elemSSZUtils.marshaler(val.Index(i), buf, currentOffsetIndex)
 				if err != nil {
-					return 0, err
+					return 0, withPath(err, indexSegment(i), currentOffsetIndex-startOffset)
 				}
 				// Write the offset.
 				offsetBuf := make([]byte, BytesPerLengthOffset)
@@ -235,16 +237,20 @@ func makeStructMarshaler(typ reflect.Type) (marshaler, error) {
 		currentOffsetIndex := startOffset + fixedLength
 		nextOffsetIndex := currentOffsetIndex
 		var err error
-		for i, f := range fields {
+		for _, f := range fields {
 			if !isVariableSizeType(f.typ) {
-				fixedIndex, err = f.sszUtils.marshaler(val.Field(i), buf, fixedIndex)
+				fieldIndex := fixedIndex
+				fixedIndex, err = f.sszUtils.marshaler(val.Field(f.index), buf, fixedIndex)
 				if err != nil {
-					return 0, err
+					return 0, withPath(err, fieldSegment(f.name), fieldIndex-startOffset)
 				}
 			} else {
+				if err := checkFieldCapacity(val.Field(f.index), f); err != nil {
+					return 0, withPath(err, fieldSegment(f.name), currentOffsetIndex-startOffset)
+				}
 				nextOffsetIndex, err = f.sszUtils.marshaler(val.Field(f.index), buf, currentOffsetIndex)
 				if err != nil {
-					return 0, err
+					return 0, withPath(err, fieldSegment(f.name), currentOffsetIndex-startOffset)
 				}
 				// Write the offset.
 				offsetBuf := make([]byte, BytesPerLengthOffset)
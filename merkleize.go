@@ -0,0 +1,194 @@
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// hashContext merkleizes chunks held in a single flat buffer. The chunks of a tree
+// are appended to the buffer from a mark, and merkleize hashes them in place, a whole
+// layer of 64-byte pairs at a time, before truncating the buffer back to the mark.
+// Trees nest like a stack: a value appends the roots of its children, each of which
+// is computed past the end of the buffer. Contexts are pooled, so once the buffer has
+// grown to the size of the largest tree, hashing does not allocate.
+type hashContext struct {
+	hasher     Hasher
+	zeroHashes [][32]byte
+	chunks     []byte
+	scratch    [64]byte
+	// workers is the number of goroutines large trees may be split across; contexts
+	// with fewer than two hash serially.
+	workers int
+	// cache holds the roots of values hashed before, or is nil if they are not cached.
+	cache *Cache
+}
+
+var hashContextPool = sync.Pool{
+	New: func() interface{} {
+		return &hashContext{}
+	},
+}
+
+// newHashContext returns a context merkleizing with h and the default cache, which
+// must be released once the roots have been computed.
+func newHashContext(h Hasher) *hashContext {
+	c := hashContextPool.Get().(*hashContext)
+	c.hasher = h
+	c.zeroHashes = zeroHashesOf(h)
+	c.cache = cacheOrDefault()
+	c.chunks = c.chunks[:0]
+	return c
+}
+
+func (c *hashContext) release() {
+	c.hasher = nil
+	c.zeroHashes = nil
+	c.workers = 0
+	c.cache = nil
+	hashContextPool.Put(c)
+}
+
+// mark returns the start of the chunks of a new tree.
+func (c *hashContext) mark() int {
+	return len(c.chunks)
+}
+
+// grow extends the buffer by n zero bytes and returns the offset of the first one.
+func (c *hashContext) grow(n int) int {
+	start := len(c.chunks)
+	if cap(c.chunks)-start < n {
+		chunks := make([]byte, start, 2*cap(c.chunks)+n)
+		copy(chunks, c.chunks)
+		c.chunks = chunks
+	}
+	c.chunks = c.chunks[:start+n]
+	for i := start; i < len(c.chunks); i++ {
+		c.chunks[i] = 0
+	}
+	return start
+}
+
+func (c *hashContext) appendRoot(root [32]byte) {
+	c.chunks = append(c.chunks, root[:]...)
+}
+
+// appendBasic appends the serialization of val, a basic value or vector of basic
+// values, which is marshaled with utils when it has no faster path.
+func (c *hashContext) appendBasic(val reflect.Value, utils *sszUtils) error {
+	switch val.Kind() {
+	case reflect.Bool:
+		if val.Bool() {
+			c.chunks = append(c.chunks, 1)
+		} else {
+			c.chunks = append(c.chunks, 0)
+		}
+		return nil
+	case reflect.Uint8:
+		c.chunks = append(c.chunks, uint8(val.Uint()))
+		return nil
+	case reflect.Uint16:
+		binary.LittleEndian.PutUint16(c.chunks[c.grow(2):], uint16(val.Uint()))
+		return nil
+	case reflect.Uint32:
+		binary.LittleEndian.PutUint32(c.chunks[c.grow(4):], uint32(val.Uint()))
+		return nil
+	case reflect.Uint64:
+		binary.LittleEndian.PutUint64(c.chunks[c.grow(8):], val.Uint())
+		return nil
+	case reflect.Slice, reflect.Array:
+		elemKind := val.Type().Elem().Kind()
+		switch {
+		case elemKind == reflect.Uint8 && val.Kind() == reflect.Slice:
+			c.chunks = append(c.chunks, val.Bytes()...)
+			return nil
+		case elemKind == reflect.Uint8 && val.CanAddr():
+			// Slicing an array with reflect allocates a slice header, so its bytes are
+			// read in place instead.
+			n := val.Len()
+			c.chunks = append(c.chunks, (*[1 << 30]byte)(unsafe.Pointer(val.UnsafeAddr()))[:n:n]...)
+			return nil
+		case elemKind == reflect.Uint8:
+			start := c.grow(val.Len())
+			for i := 0; i < val.Len(); i++ {
+				c.chunks[start+i] = uint8(val.Index(i).Uint())
+			}
+			return nil
+		case isBasicType(elemKind):
+			// The elements are basic values, which all have a faster path.
+			for i := 0; i < val.Len(); i++ {
+				if err := c.appendBasic(val.Index(i), nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	start := c.grow(int(determineSize(val)))
+	_, err := utils.marshaler(val, c.chunks, uint64(start))
+	return err
+}
+
+// merkleize returns the root of the chunks appended since start, padded with zero
+// chunks up to limit leaves, or to a power of two if the tree has no limit. The
+// last chunk is right-padded with zero bytes. The buffer is truncated back to start.
+func (c *hashContext) merkleize(start int, limit uint64, hasLimit bool) ([32]byte, error) {
+	if tail := (len(c.chunks) - start) % BytesPerChunk; tail != 0 {
+		c.grow(BytesPerChunk - tail)
+	}
+	count := uint64(len(c.chunks)-start) / uint64(BytesPerChunk)
+	if !hasLimit {
+		limit = count
+	}
+	if count > limit {
+		c.chunks = c.chunks[:start]
+		return [32]byte{}, fmt.Errorf("chunk count = %d cannot be greater than padding = %d", count, limit)
+	}
+	depth := treeDepth(limit)
+	if count == 0 {
+		return c.zeroHashes[depth], nil
+	}
+	if c.workers > 1 && count >= parallelMinChunks {
+		return c.merkleizeParallel(start, count, depth), nil
+	}
+	return c.hashLayers(start, count, 0, depth), nil
+}
+
+// hashLayers returns the root of a tree of the given depth whose nodes at level from
+// are the count chunks from start, followed by zero hashes. The buffer is truncated
+// back to start.
+func (c *hashContext) hashLayers(start int, count uint64, from uint64, depth uint64) [32]byte {
+	for level := from; level < depth; level++ {
+		if count%2 == 1 {
+			c.chunks = append(c.chunks, c.zeroHashes[level][:]...)
+			count++
+		}
+		layer := c.chunks[start:]
+		c.hasher.HashPairs(layer[:len(layer)/2], layer)
+		count /= 2
+		c.chunks = c.chunks[:start+int(count)*BytesPerChunk]
+	}
+	root := toBytes32(c.chunks[start:])
+	c.chunks = c.chunks[:start]
+	return root
+}
+
+// mixIn returns the hash of root and the chunk serializing value, such as the
+// length of a list or the selector of a union.
+func (c *hashContext) mixIn(root [32]byte, value uint64) [32]byte {
+	copy(c.scratch[:32], root[:])
+	binary.LittleEndian.PutUint64(c.scratch[32:], value)
+	for i := 40; i < len(c.scratch); i++ {
+		c.scratch[i] = 0
+	}
+	return c.hasher.Hash(c.scratch[:])
+}
+
+// rootOf computes the root of val using utils with a pooled context.
+func rootOf(val reflect.Value, utils *sszUtils, maxCapacity uint64, h Hasher) ([32]byte, error) {
+	c := newHashContext(h)
+	defer c.release()
+	return utils.hasher(val, maxCapacity, c)
+}
@@ -0,0 +1,134 @@
+package ssz
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/minio/sha256-simd"
+)
+
+type benchValidator struct {
+	Pubkey                     [48]byte
+	WithdrawalCredentials      [32]byte
+	EffectiveBalance           uint64
+	Slashed                    bool
+	ActivationEligibilityEpoch uint64
+	ActivationEpoch            uint64
+	ExitEpoch                  uint64
+	WithdrawableEpoch          uint64
+}
+
+type benchRegistry struct {
+	Validators []benchValidator `ssz-max:"1099511627776"`
+	Balances   []uint64         `ssz-max:"1099511627776"`
+}
+
+func newBenchRegistry(n int) *benchRegistry {
+	r := &benchRegistry{
+		Validators: make([]benchValidator, n),
+		Balances:   make([]uint64, n),
+	}
+	for i := range r.Validators {
+		r.Validators[i].Pubkey[0] = byte(i)
+		r.Validators[i].Pubkey[1] = byte(i >> 8)
+		r.Validators[i].EffectiveBalance = 32000000000
+		r.Validators[i].ExitEpoch = 1<<64 - 1
+		r.Balances[i] = uint64(i)
+	}
+	return r
+}
+
+// naiveMerkleize pads chunks with zero chunks up to limit, and hashes the tree one
+// pair at a time.
+func naiveMerkleize(chunks [][32]byte, limit int) [32]byte {
+	size := 1
+	for size < limit {
+		size *= 2
+	}
+	layer := make([][32]byte, size)
+	copy(layer, chunks)
+	for len(layer) > 1 {
+		next := make([][32]byte, len(layer)/2)
+		for i := range next {
+			next[i] = sha256.Sum256(append(layer[2*i][:], layer[2*i+1][:]...))
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+func TestHashContext_Merkleize(t *testing.T) {
+	c := newHashContext(SHA256)
+	defer c.release()
+	for limit := 1; limit <= 33; limit++ {
+		for count := 0; count <= limit; count++ {
+			chunks := make([][32]byte, count)
+			for i := range chunks {
+				chunks[i][0] = byte(i + 1)
+				chunks[i][31] = byte(limit)
+			}
+			// Hash past a pending chunk, as a nested tree would.
+			c.appendRoot([32]byte{0xff})
+			start := c.mark()
+			for _, chunk := range chunks {
+				c.appendRoot(chunk)
+			}
+			got, err := c.merkleize(start, uint64(limit), true /* has limit */)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := naiveMerkleize(chunks, limit); got != want {
+				t.Errorf("merkleize(%d chunks, limit %d) = %#x, want %#x", count, limit, got, want)
+			}
+			if c.mark() != start || !bytes.Equal(c.chunks[start-32:], append([]byte{0xff}, make([]byte, 31)...)) {
+				t.Fatalf("merkleize(%d chunks, limit %d) did not restore the pending chunk", count, limit)
+			}
+			c.chunks = c.chunks[:0]
+		}
+	}
+}
+
+func TestHashContext_MerkleizeOverLimit(t *testing.T) {
+	c := newHashContext(SHA256)
+	defer c.release()
+	c.appendRoot([32]byte{1})
+	c.appendRoot([32]byte{2})
+	if _, err := c.merkleize(0, 1, true /* has limit */); err == nil {
+		t.Error("Expected error merkleizing more chunks than the limit")
+	}
+	if c.mark() != 0 {
+		t.Errorf("Expected the chunks to be dropped, %d bytes remain", c.mark())
+	}
+}
+
+func TestHashTreeRoot_DoesNotAllocate(t *testing.T) {
+	ToggleCache(false)
+	defer ToggleCache(true)
+	r := newBenchRegistry(1024)
+	if _, err := HashTreeRoot(r); err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		if _, err := HashTreeRoot(r); err != nil {
+			t.Fatal(err)
+		}
+	})
+	// The pool can drop the context, whose buffer then has to grow again, but hashing
+	// should not allocate per validator.
+	if allocs > 16 {
+		t.Errorf("HashTreeRoot() allocated %v times, want at most 16", allocs)
+	}
+}
+
+func BenchmarkHashTreeRoot_ValidatorRegistry(b *testing.B) {
+	ToggleCache(false)
+	defer ToggleCache(true)
+	r := newBenchRegistry(16384)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := HashTreeRoot(r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
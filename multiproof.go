@@ -0,0 +1,128 @@
+package ssz
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ProveMulti returns the leaves at each of the generalized indices gindices of the
+// Merkle tree of val, together with the helper nodes needed to prove all of them at
+// once against the hash tree root of val. Helper nodes are ordered by decreasing
+// generalized index, as in get_helper_indices in the specification, and nodes which
+// can be computed from the leaves or other helpers are left out. Helper nodes in the
+// padding of a list are taken from the table of zero hashes rather than computed:
+//  leaves, proof, err := ProveMulti(state, []uint64{slotIndex, rootIndex})
+//  if err != nil {
+//      return fmt.Errorf("failed to prove: %v", err)
+//  }
+func ProveMulti(val interface{}, gindices []uint64) (leaves [][32]byte, proof [][32]byte, err error) {
+	if val == nil {
+		return nil, nil, errors.New("untyped nil is not supported")
+	}
+	for _, gindex := range gindices {
+		if gindex == 0 {
+			return nil, nil, errors.New("generalized index 0 is not part of any tree")
+		}
+	}
+	rval := reflect.ValueOf(val)
+	utils, err := cachedSSZUtils(rval.Type())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get ssz utils for type: %v: %v", rval.Type(), err)
+	}
+	layout, err := layoutOf(rval, rval.Type(), utils, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not determine tree of type: %v: %v", rval.Type(), err)
+	}
+	leaves = make([][32]byte, len(gindices))
+	for i, gindex := range gindices {
+		if leaves[i], err = layout.walk(gindex, nil); err != nil {
+			return nil, nil, fmt.Errorf("could not prove generalized index %d of type: %v: %v", gindex, rval.Type(), err)
+		}
+	}
+	helpers := helperIndices(gindices)
+	proof = make([][32]byte, len(helpers))
+	for i, gindex := range helpers {
+		// Helpers are siblings of nodes on the path of a leaf, so they always exist.
+		if proof[i], err = layout.walk(gindex, nil); err != nil {
+			return nil, nil, fmt.Errorf("could not compute helper node %d of type: %v: %v", gindex, rval.Type(), err)
+		}
+	}
+	return leaves, proof, nil
+}
+
+// VerifyMultiproof checks a multiproof returned by ProveMulti, reporting whether the
+// leaves at generalized indices gindices and the helper nodes in proof combine into
+// the given root. Nodes are hashed with the Hasher set by SetHasher.
+func VerifyMultiproof(root [32]byte, leaves [][32]byte, proof [][32]byte, gindices []uint64) bool {
+	if len(leaves) != len(gindices) {
+		return false
+	}
+	helpers := helperIndices(gindices)
+	if len(proof) != len(helpers) {
+		return false
+	}
+	nodes := make(map[uint64][32]byte, len(leaves)+len(proof))
+	for i, gindex := range gindices {
+		if gindex == 0 {
+			return false
+		}
+		nodes[gindex] = leaves[i]
+	}
+	for i, gindex := range helpers {
+		nodes[gindex] = proof[i]
+	}
+	// Parents are always found after their children when walking the generalized
+	// indices in decreasing order, so a single pass computes every node up to the root.
+	keys := make([]uint64, 0, len(nodes))
+	for gindex := range nodes {
+		keys = append(keys, gindex)
+	}
+	sortDescending(keys)
+	h := currentHasher()
+	for i := 0; i < len(keys); i++ {
+		gindex := keys[i]
+		if gindex == 1 {
+			continue
+		}
+		sibling, hasSibling := nodes[gindex^1]
+		if _, hasParent := nodes[gindex/2]; !hasSibling || hasParent {
+			continue
+		}
+		node := nodes[gindex]
+		if gindex&1 == 1 {
+			nodes[gindex/2] = hash2(h, sibling[:], node[:])
+		} else {
+			nodes[gindex/2] = hash2(h, node[:], sibling[:])
+		}
+		keys = append(keys, gindex/2)
+	}
+	computed, ok := nodes[1]
+	return ok && computed == root
+}
+
+// helperIndices returns the generalized indices of the nodes needed, besides the
+// nodes at gindices, to compute the root of the tree, in decreasing order.
+func helperIndices(gindices []uint64) []uint64 {
+	branch := make(map[uint64]bool)
+	path := make(map[uint64]bool)
+	for _, gindex := range gindices {
+		for index := gindex; index > 1; index /= 2 {
+			branch[index^1] = true
+			path[index] = true
+		}
+	}
+	var helpers []uint64
+	for index := range branch {
+		if !path[index] {
+			helpers = append(helpers, index)
+		}
+	}
+	sortDescending(helpers)
+	return helpers
+}
+
+func sortDescending(indices []uint64) {
+	sort.Slice(indices, func(i, j int) bool { return indices[i] > indices[j] })
+}
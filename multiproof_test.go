@@ -0,0 +1,103 @@
+package ssz_test
+
+import (
+	"reflect"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+func TestProveMulti_VerifyMultiproof(t *testing.T) {
+	root, err := ssz.HashTreeRoot(proofItemExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := reflect.TypeOf(proofItemExample)
+	paths := [][]interface{}{
+		{"Slot"},
+		{"Roots", 0},
+		{"Roots", 7},
+		{"Roots", ssz.LengthPathElement},
+		{"Balances", 0},
+		{"Forks", 1, "Epoch"},
+		{"Nested", "Field3"},
+	}
+	gindices := make([]uint64, len(paths))
+	for i, path := range paths {
+		if gindices[i], err = ssz.GeneralizedIndex(typ, path...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	leaves, proof, err := ssz.ProveMulti(proofItemExample, gindices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, gindex := range gindices {
+		leaf, _, err := ssz.Prove(proofItemExample, gindex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if leaves[i] != leaf {
+			t.Errorf("Generalized index %d: expected leaf %#x, received %#x", gindex, leaf, leaves[i])
+		}
+	}
+	// A multiproof never needs more helpers than the single branches combined.
+	singles := 0
+	for _, gindex := range gindices {
+		_, branch, err := ssz.Prove(proofItemExample, gindex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		singles += len(branch)
+	}
+	if len(proof) >= singles {
+		t.Errorf("Expected fewer than %d helper nodes, received %d", singles, len(proof))
+	}
+	if !ssz.VerifyMultiproof(root, leaves, proof, gindices) {
+		t.Error("Expected multiproof to verify")
+	}
+
+	tampered := append([][32]byte{}, leaves...)
+	tampered[1][0] ^= 1
+	if ssz.VerifyMultiproof(root, tampered, proof, gindices) {
+		t.Error("Expected multiproof with a tampered leaf not to verify")
+	}
+	if ssz.VerifyMultiproof(root, leaves, proof[1:], gindices) {
+		t.Error("Expected multiproof with a missing helper not to verify")
+	}
+	if ssz.VerifyMultiproof(root, leaves[1:], proof, gindices) {
+		t.Error("Expected multiproof with a missing leaf not to verify")
+	}
+}
+
+func TestProveMulti_SingleLeafMatchesProve(t *testing.T) {
+	root, err := ssz.HashTreeRoot(proofItemExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for gindex := uint64(1); gindex < 1<<10; gindex++ {
+		leaf, branch, err := ssz.Prove(proofItemExample, gindex)
+		if err != nil {
+			continue
+		}
+		leaves, proof, err := ssz.ProveMulti(proofItemExample, []uint64{gindex})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if leaves[0] != leaf || !reflect.DeepEqual(proof, branch) && len(branch) > 0 {
+			t.Errorf("Generalized index %d: multiproof differs from single branch", gindex)
+		}
+		if !ssz.VerifyMultiproof(root, leaves, proof, []uint64{gindex}) {
+			t.Errorf("Generalized index %d: expected multiproof to verify", gindex)
+		}
+	}
+}
+
+func TestProveMulti_InvalidIndex(t *testing.T) {
+	if _, _, err := ssz.ProveMulti(proofItemExample, []uint64{8, 16}); err == nil {
+		t.Error("Expected error proving below a basic leaf, received nil")
+	}
+	if _, _, err := ssz.ProveMulti(proofItemExample, []uint64{0}); err == nil {
+		t.Error("Expected error proving generalized index 0, received nil")
+	}
+}
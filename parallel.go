@@ -0,0 +1,98 @@
+package ssz
+
+import (
+	"reflect"
+	"sync"
+)
+
+const (
+	// parallelMinElements is the number of elements from which the roots of the
+	// elements of a list or vector are split across workers.
+	parallelMinElements = 256
+	// parallelMinChunks is the number of chunks from which a tree is split into
+	// subtrees merkleized by different workers.
+	parallelMinChunks = 1024
+)
+
+// appendElementRoots appends the roots of the elements of val computed with hash,
+// splitting the elements into contiguous ranges hashed by the workers of c when there
+// are enough of them. Each worker hashes with its own context, serially. The error of
+// the first failing element is returned, whichever worker hashes it.
+func (c *hashContext) appendElementRoots(val reflect.Value, hash hasher) error {
+	n := val.Len()
+	if c.workers < 2 || n < parallelMinElements {
+		for i := 0; i < n; i++ {
+			r, err := hash(val.Index(i), 0, c)
+			if err != nil {
+				return withPath(err, indexSegment(i), 0)
+			}
+			c.appendRoot(r)
+		}
+		return nil
+	}
+	roots := c.chunks[c.grow(n*BytesPerChunk):]
+	perWorker := (n + c.workers - 1) / c.workers
+	errs := make([]error, c.workers)
+	var wg sync.WaitGroup
+	for w := 0; w*perWorker < n; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			wc := newHashContext(c.hasher)
+			wc.cache = c.cache
+			defer wc.release()
+			end := (w + 1) * perWorker
+			if end > n {
+				end = n
+			}
+			for i := w * perWorker; i < end; i++ {
+				r, err := hash(val.Index(i), 0, wc)
+				if err != nil {
+					errs[w] = withPath(err, indexSegment(i), 0)
+					return
+				}
+				copy(roots[i*BytesPerChunk:], r[:])
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// merkleizeParallel returns the root of a tree of the given depth whose leaves are
+// the count chunks from start. The chunks are split into one power-of-two subtree per
+// worker, whose roots are merkleized by the workers before the top of the tree is
+// hashed. The buffer is truncated back to start.
+func (c *hashContext) merkleizeParallel(start int, count uint64, depth uint64) [32]byte {
+	workers := uint64(c.workers)
+	subDepth := treeDepth((count + workers - 1) / workers)
+	subSize := uint64(1) << subDepth
+	roots := make([][32]byte, (count+subSize-1)/subSize)
+	var wg sync.WaitGroup
+	for i := range roots {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			wc := newHashContext(c.hasher)
+			defer wc.release()
+			lo := start + i*int(subSize)*BytesPerChunk
+			hi := lo + int(subSize)*BytesPerChunk
+			if end := start + int(count)*BytesPerChunk; hi > end {
+				hi = end
+			}
+			wc.chunks = append(wc.chunks, c.chunks[lo:hi]...)
+			roots[i] = wc.hashLayers(0, uint64(hi-lo)/uint64(BytesPerChunk), 0, subDepth)
+		}(i)
+	}
+	wg.Wait()
+	c.chunks = c.chunks[:start]
+	for _, r := range roots {
+		c.appendRoot(r)
+	}
+	return c.hashLayers(start, uint64(len(roots)), subDepth, depth)
+}
@@ -0,0 +1,122 @@
+package ssz_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type parallelState struct {
+	Validators []testValidator `ssz-max:"1099511627776"`
+	Balances   []uint64        `ssz-max:"1099511627776"`
+	Roots      [][]byte        `ssz-size:"?,32" ssz-max:"16777216"`
+	Mixes      [2048][32]byte
+}
+
+type parallelItem struct {
+	Slot    uint64
+	Payload ssz.Union `ssz-union:"None,uint64"`
+}
+
+type parallelItems struct {
+	Items []parallelItem `ssz-max:"4096"`
+}
+
+func newParallelState(n int) *parallelState {
+	s := &parallelState{
+		Validators: make([]testValidator, n),
+		Balances:   make([]uint64, n),
+		Roots:      make([][]byte, n),
+	}
+	for i := 0; i < n; i++ {
+		s.Validators[i] = newTestValidator(i)
+		s.Balances[i] = uint64(i)
+		s.Roots[i] = append([]byte{byte(i >> 8), byte(i)}, make([]byte, 30)...)
+	}
+	for i := range s.Mixes {
+		s.Mixes[i][0] = byte(i)
+	}
+	return s
+}
+
+func TestHashTreeRootWithOptions_ParallelMatchesSerial(t *testing.T) {
+	for _, useCache := range []bool{false, true} {
+		ssz.ToggleCache(useCache)
+		for _, n := range []int{0, 1, 255, 256, 1000, 1025, 4097} {
+			state := newParallelState(n)
+			want, err := ssz.HashTreeRoot(state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, workers := range []int{1, 2, 3, 7, 0} {
+				t.Run(fmt.Sprintf("cache=%v/n=%d/workers=%d", useCache, n, workers), func(t *testing.T) {
+					got, err := ssz.HashTreeRootWithOptions(state, ssz.Parallel(workers))
+					if err != nil {
+						t.Fatal(err)
+					}
+					if got != want {
+						t.Errorf("HashTreeRootWithOptions() = %#x, want %#x", got, want)
+					}
+				})
+			}
+		}
+	}
+	ssz.ToggleCache(true)
+}
+
+func TestHashTreeRootWithOptions_ParallelWithHasher(t *testing.T) {
+	h := ssz.NewHasher(func(data []byte) [32]byte {
+		return ssz.SHA256.Hash(append([]byte{1}, data...))
+	})
+	state := newParallelState(2000)
+	want, err := ssz.HashTreeRootWithHasher(state, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ssz.HashTreeRootWithOptions(state, ssz.WithHasher(h), ssz.Parallel(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("HashTreeRootWithOptions() = %#x, want %#x", got, want)
+	}
+	if _, err := ssz.HashTreeRootWithOptions(state, ssz.WithHasher(nil)); err == nil {
+		t.Error("Expected error for a nil hasher")
+	}
+}
+
+func TestHashTreeRootWithOptions_ParallelErrorIsDeterministic(t *testing.T) {
+	items := parallelItems{Items: make([]parallelItem, 3000)}
+	// Both items have an unknown selector, and the first one is reported whichever
+	// worker hashes it first.
+	items.Items[2999].Payload.Selector = 5
+	items.Items[1234].Payload.Selector = 5
+	for i := 0; i < 10; i++ {
+		_, err := ssz.HashTreeRootWithOptions(items, ssz.Parallel(8))
+		var hashErr *ssz.HashError
+		if !errors.As(err, &hashErr) || !errors.Is(err, ssz.ErrUnknownSelector) {
+			t.Fatalf("Expected a *HashError wrapping ErrUnknownSelector, received %v", err)
+		}
+		if hashErr.Path != "parallelItems.Items[1234].Payload" {
+			t.Errorf("Expected path parallelItems.Items[1234].Payload, received %s", hashErr.Path)
+		}
+	}
+}
+
+func BenchmarkHashTreeRootWithOptions_Parallel(b *testing.B) {
+	ssz.ToggleCache(false)
+	defer ssz.ToggleCache(true)
+	state := newParallelState(100000)
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if _, err := ssz.HashTreeRootWithOptions(state, ssz.Parallel(workers)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
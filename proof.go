@@ -0,0 +1,364 @@
+package ssz
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+var bitlistType = reflect.TypeOf(bitfield.Bitlist{})
+
+// merkleLayout describes the Merkle tree a value is hashed into, mirroring its
+// hasher: the chunks of the value are padded with zero hashes into a tree of the
+// given depth, whose root is then mixed in with a length or selector chunk, if any.
+type merkleLayout struct {
+	chunks [][32]byte
+	depth  uint64
+	mixIn  []byte
+	// child returns the layout of the value whose root is chunk i. It is nil when the
+	// chunks hold packed basic values, or roots of values we cannot look into.
+	child func(i int) (*merkleLayout, error)
+}
+
+// Prove returns the leaf at generalized index gindex of the Merkle tree of val,
+// together with the branch proving it against the hash tree root of val. The
+// branch lists sibling nodes from the bottom of the tree up, as expected by
+// is_valid_merkle_branch in the specification. Generalized index 1 is the root
+// itself, and the length of a list is found at generalized index 3 of its tree:
+//  leaf, branch, err := Prove(state, gindex)
+//  if err != nil {
+//      return fmt.Errorf("failed to prove: %v", err)
+//  }
+func Prove(val interface{}, gindex uint64) (leaf [32]byte, branch [][32]byte, err error) {
+	if val == nil {
+		return [32]byte{}, nil, errors.New("untyped nil is not supported")
+	}
+	if gindex == 0 {
+		return [32]byte{}, nil, errors.New("generalized index 0 is not part of any tree")
+	}
+	rval := reflect.ValueOf(val)
+	utils, err := cachedSSZUtils(rval.Type())
+	if err != nil {
+		return [32]byte{}, nil, fmt.Errorf("could not get ssz utils for type: %v: %v", rval.Type(), err)
+	}
+	layout, err := layoutOf(rval, rval.Type(), utils, 0)
+	if err != nil {
+		return [32]byte{}, nil, fmt.Errorf("could not determine tree of type: %v: %v", rval.Type(), err)
+	}
+	leaf, branch, err = layout.prove(gindex)
+	if err != nil {
+		return [32]byte{}, nil, fmt.Errorf("could not prove generalized index %d of type: %v: %v", gindex, rval.Type(), err)
+	}
+	return leaf, branch, nil
+}
+
+// prove walks down the tree along the path of gindex, collecting the siblings
+// of every node on the way.
+func (l *merkleLayout) prove(gindex uint64) ([32]byte, [][32]byte, error) {
+	// Siblings are collected from the top down and reversed at the end.
+	var siblings [][32]byte
+	leaf, err := l.walk(gindex, func(sibling func() [32]byte) {
+		siblings = append(siblings, sibling())
+	})
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	branch := make([][32]byte, len(siblings))
+	for i, s := range siblings {
+		branch[len(siblings)-1-i] = s
+	}
+	return leaf, branch, nil
+}
+
+// walk descends the tree along the path of gindex and returns the node it ends at.
+// The sibling of every node on the way is passed to visit, if set, from the top
+// down. Siblings are only computed when visit calls for them.
+func (l *merkleLayout) walk(gindex uint64, visit func(sibling func() [32]byte)) ([32]byte, error) {
+	if visit == nil {
+		visit = func(func() [32]byte) {}
+	}
+	pathLength := bitLength(gindex) - 1
+	nextBit := func() uint64 {
+		pathLength--
+		return (gindex >> pathLength) & 1
+	}
+	for {
+		if pathLength == 0 {
+			return l.root(), nil
+		}
+		if l.mixIn != nil {
+			if nextBit() == 1 {
+				if pathLength > 0 {
+					return [32]byte{}, errors.New("generalized index goes below a length or selector leaf")
+				}
+				visit(func() [32]byte { return l.node(l.depth, 0) })
+				return toBytes32(l.mixIn), nil
+			}
+			visit(func() [32]byte { return toBytes32(l.mixIn) })
+			if pathLength == 0 {
+				return l.node(l.depth, 0), nil
+			}
+		}
+		level := l.depth
+		index := uint64(0)
+		for level > 0 && pathLength > 0 {
+			bit := nextBit()
+			level--
+			siblingLevel, siblingIndex := level, 2*index+1-bit
+			visit(func() [32]byte { return l.node(siblingLevel, siblingIndex) })
+			index = 2*index + bit
+		}
+		if pathLength == 0 {
+			return l.node(level, index), nil
+		}
+		if index >= uint64(len(l.chunks)) {
+			return [32]byte{}, errors.New("generalized index goes below a padding chunk")
+		}
+		if l.child == nil {
+			return [32]byte{}, errors.New("generalized index goes below a leaf chunk")
+		}
+		child, err := l.child(int(index))
+		if err != nil {
+			return [32]byte{}, err
+		}
+		if child == nil {
+			return [32]byte{}, errors.New("generalized index goes below a leaf chunk")
+		}
+		l = child
+	}
+}
+
+// root returns the hash tree root of the tree.
+func (l *merkleLayout) root() [32]byte {
+	root := l.node(l.depth, 0)
+	if l.mixIn != nil {
+		return hash2(currentHasher(), root[:], l.mixIn)
+	}
+	return root
+}
+
+// node returns the root of the subtree at the given level, counted from the
+// chunks up, and index within that level. Subtrees past the last chunk are
+// zero hashes, so padding is never materialised.
+func (l *merkleLayout) node(level uint64, index uint64) [32]byte {
+	if index<<level >= uint64(len(l.chunks)) {
+		return zeroHashesOf(currentHasher())[level]
+	}
+	if level == 0 {
+		return l.chunks[index]
+	}
+	left := l.node(level-1, 2*index)
+	right := l.node(level-1, 2*index+1)
+	return hash2(currentHasher(), left[:], right[:])
+}
+
+// newMerkleLayout pads the chunks into a tree with room for padding chunks.
+func newMerkleLayout(chunks [][32]byte, padding uint64) (*merkleLayout, error) {
+	if uint64(len(chunks)) > padding {
+		return nil, fmt.Errorf("chunk count = %d cannot be greater than padding = %d", len(chunks), padding)
+	}
+	return &merkleLayout{chunks: chunks, depth: treeDepth(padding)}, nil
+}
+
+func lengthMixIn(length uint64) []byte {
+	mixIn := make([]byte, 32)
+	binary.LittleEndian.PutUint64(mixIn, length)
+	return mixIn
+}
+
+func leafLayout(root [32]byte) *merkleLayout {
+	return &merkleLayout{chunks: [][32]byte{root}}
+}
+
+// layoutOf determines the Merkle tree of val, which is hashed as typ using utils.
+// It classifies typ with kindOf, as makeHasher does.
+func layoutOf(val reflect.Value, typ reflect.Type, utils *sszUtils, maxCapacity uint64) (*merkleLayout, error) {
+	if typ.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return leafLayout([32]byte{}), nil
+		}
+		elemUtils, err := cachedSSZUtils(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return layoutOf(val.Elem(), typ.Elem(), elemUtils, maxCapacity)
+	}
+	kind, ok := kindOf(typ, false)
+	switch {
+	case implements(typ, hashRootType) || ok && kind == KindBasic:
+		// We cannot look inside types which hash themselves, and basic values,
+		// including big integers, are a chunk of their own.
+		root, err := rootOf(val, utils, maxCapacity, currentHasher())
+		if err != nil {
+			return nil, err
+		}
+		return leafLayout(root), nil
+	case !ok:
+		return nil, fmt.Errorf("type %v is not hashable", typ)
+	case kind == KindUnion:
+		return unionLayout(val, utils)
+	case kind == KindVector && isPacked(typ.Elem()):
+		chunks, err := packedChunks(val, typ, utils)
+		if err != nil {
+			return nil, err
+		}
+		return newMerkleLayout(chunks, uint64(len(chunks)))
+	case kind == KindVector:
+		return compositeLayout(val, typ, uint64(val.Len()), false /* mix in length */)
+	case kind == KindList && isPacked(typ.Elem()):
+		return basicListLayout(val, typ, maxCapacity)
+	case kind == KindList:
+		limit := listLimit(typ, maxCapacity, uint64(val.Len()))
+		return compositeLayout(val, typ, limit, true /* mix in length */)
+	default:
+		return structLayout(val, typ)
+	}
+}
+
+// packedChunks serializes val and packs it into chunks.
+func packedChunks(val reflect.Value, typ reflect.Type, utils *sszUtils) ([][32]byte, error) {
+	buf := make([]byte, determineSize(val))
+	if _, err := utils.marshaler(val, buf, 0); err != nil {
+		return nil, err
+	}
+	return toChunks(buf), nil
+}
+
+// toChunks packs serialized basic values into chunks, right-padding the last one.
+func toChunks(serialized []byte) [][32]byte {
+	chunks := make([][32]byte, (len(serialized)+BytesPerChunk-1)/BytesPerChunk)
+	for i := range chunks {
+		copy(chunks[i][:], serialized[i*BytesPerChunk:])
+	}
+	return chunks
+}
+
+func bitlistLayout(bits bitfield.Bitlist, maxCapacity uint64) (*merkleLayout, error) {
+	var chunks [][32]byte
+	length := uint64(0)
+	if len(bits) > 0 {
+		chunks = toChunks(bits.Bytes())
+		length = bits.Len()
+	}
+	layout, err := newMerkleLayout(chunks, (maxCapacity+255)/256)
+	if err != nil {
+		return nil, err
+	}
+	layout.mixIn = lengthMixIn(length)
+	return layout, nil
+}
+
+func basicListLayout(val reflect.Value, typ reflect.Type, maxCapacity uint64) (*merkleLayout, error) {
+	elemUtils, err := cachedSSZUtils(typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	elemSize := determineFixedSize(reflect.New(typ.Elem()).Elem(), typ.Elem())
+	limit := (maxCapacity*elemSize + 31) / 32
+	if limit == 0 {
+		limit = 1
+	}
+	buf := make([]byte, uint64(val.Len())*elemSize)
+	index := uint64(0)
+	for i := 0; i < val.Len(); i++ {
+		if index, err = elemUtils.marshaler(val.Index(i), buf, index); err != nil {
+			return nil, err
+		}
+	}
+	layout, err := newMerkleLayout(toChunks(buf), limit)
+	if err != nil {
+		return nil, err
+	}
+	layout.mixIn = lengthMixIn(uint64(val.Len()))
+	return layout, nil
+}
+
+// compositeLayout merkleizes the roots of the elements of a vector or list.
+func compositeLayout(val reflect.Value, typ reflect.Type, padding uint64, mixInLength bool) (*merkleLayout, error) {
+	elemUtils, err := cachedSSZUtils(typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([][32]byte, val.Len())
+	for i := range chunks {
+		if chunks[i], err = rootOf(val.Index(i), elemUtils, 0, currentHasher()); err != nil {
+			return nil, err
+		}
+	}
+	layout, err := newMerkleLayout(chunks, padding)
+	if err != nil {
+		return nil, err
+	}
+	if mixInLength {
+		layout.mixIn = lengthMixIn(uint64(val.Len()))
+	}
+	layout.child = func(i int) (*merkleLayout, error) {
+		return layoutOf(val.Index(i), typ.Elem(), elemUtils, 0)
+	}
+	return layout, nil
+}
+
+func structLayout(val reflect.Value, typ reflect.Type) (*merkleLayout, error) {
+	fields, err := cachedStructFields(typ)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([][32]byte, len(fields))
+	for i, f := range fields {
+		fieldVal := val.Field(f.index)
+		if fieldVal.Type() == bitlistType {
+			chunks[i], err = BitlistRoot(fieldVal.Interface().(bitfield.Bitlist), f.capacity)
+		} else {
+			chunks[i], err = rootOf(fieldVal, f.sszUtils, f.capacity, currentHasher())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash field %s of struct: %v", f.name, err)
+		}
+	}
+	layout, err := newMerkleLayout(chunks, uint64(len(fields)))
+	if err != nil {
+		return nil, err
+	}
+	layout.child = func(i int) (*merkleLayout, error) {
+		f := fields[i]
+		fieldVal := val.Field(f.index)
+		if kind, _ := kindOf(f.typ, true); kind == KindBitlist {
+			// Bitlist fields are hashed with their capacity rather than as byte lists.
+			return bitlistLayout(fieldVal.Interface().(bitfield.Bitlist), f.capacity)
+		}
+		return layoutOf(fieldVal, f.typ, f.sszUtils, f.capacity)
+	}
+	return layout, nil
+}
+
+func unionLayout(val reflect.Value, utils *sszUtils) (*merkleLayout, error) {
+	// Hashing the union checks its selector against its variants.
+	if _, err := rootOf(val, utils, 0, currentHasher()); err != nil {
+		return nil, err
+	}
+	u := val.Interface().(Union)
+	mixIn := make([]byte, 32)
+	mixIn[0] = u.Selector
+	if u.Value == nil {
+		return &merkleLayout{chunks: [][32]byte{{}}, mixIn: mixIn}, nil
+	}
+	value := reflect.ValueOf(u.Value)
+	valueUtils, err := cachedSSZUtils(value.Type())
+	if err != nil {
+		return nil, err
+	}
+	root, err := rootOf(value, valueUtils, 0, currentHasher())
+	if err != nil {
+		return nil, err
+	}
+	return &merkleLayout{
+		chunks: [][32]byte{root},
+		mixIn:  mixIn,
+		child: func(int) (*merkleLayout, error) {
+			return layoutOf(value, value.Type(), valueUtils, 0)
+		},
+	}, nil
+}
@@ -0,0 +1,125 @@
+package ssz_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type proofItem struct {
+	Slot     uint64
+	Roots    [][32]byte       `ssz-max:"8"`
+	Balances []uint64         `ssz-max:"16"`
+	Forks    []fork           `ssz-max:"4"`
+	Bits     bitfield.Bitlist `ssz-max:"64"`
+	Nested   nestedItem
+}
+
+var proofItemExample = proofItem{
+	Slot:     9,
+	Roots:    [][32]byte{{1}, {2}, {3}},
+	Balances: []uint64{1, 2, 3, 4, 5},
+	Forks:    []fork{forkExample, forkExample},
+	Bits:     bitfield.Bitlist{0x0f, 0x01},
+	Nested:   nestedItemExample,
+}
+
+// verifyBranch computes the root implied by a leaf and its branch, as in
+// is_valid_merkle_branch.
+func verifyBranch(leaf [32]byte, branch [][32]byte, gindex uint64) [32]byte {
+	node := leaf
+	for _, sibling := range branch {
+		if gindex&1 == 1 {
+			node = sha256.Sum256(append(sibling[:], node[:]...))
+		} else {
+			node = sha256.Sum256(append(node[:], sibling[:]...))
+		}
+		gindex >>= 1
+	}
+	return node
+}
+
+func TestProve_AllGeneralizedIndices(t *testing.T) {
+	root, err := ssz.HashTreeRoot(proofItemExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proved := 0
+	for gindex := uint64(1); gindex < 1<<12; gindex++ {
+		leaf, branch, err := ssz.Prove(proofItemExample, gindex)
+		if err != nil {
+			// Indices below leaf chunks do not exist in the tree.
+			continue
+		}
+		proved++
+		if got := verifyBranch(leaf, branch, gindex); got != root {
+			t.Errorf("Generalized index %d: expected root %#x, received %#x", gindex, root, got)
+		}
+	}
+	if proved < 50 {
+		t.Errorf("Expected to prove at least 50 generalized indices, proved %d", proved)
+	}
+}
+
+func TestProve_Leaves(t *testing.T) {
+	// The struct has 6 fields, so they are leaves 8 through 13 of a tree of depth 3.
+	slotRoot := [32]byte{9}
+	leaf, branch, err := ssz.Prove(proofItemExample, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf != slotRoot || len(branch) != 3 {
+		t.Errorf("Expected leaf %#x with 3 siblings, received %#x with %d", slotRoot, leaf, len(branch))
+	}
+	// The length of Roots is the right child of its root: 9*2+1.
+	leaf, _, err = ssz.Prove(proofItemExample, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf != [32]byte{3} {
+		t.Errorf("Expected length leaf of 3, received %#x", leaf)
+	}
+	// Roots has a capacity of 8, so its elements are leaves 8 through 15 below
+	// its contents root at 18, which are 18*8 to 18*8+7.
+	leaf, _, err = ssz.Prove(proofItemExample, 18*8+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf != [32]byte{2} {
+		t.Errorf("Expected second root, received %#x", leaf)
+	}
+	// Padding elements are zero leaves.
+	leaf, _, err = ssz.Prove(proofItemExample, 18*8+7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf != [32]byte{} {
+		t.Errorf("Expected zero leaf, received %#x", leaf)
+	}
+}
+
+func TestProve_Root(t *testing.T) {
+	root, err := ssz.HashTreeRoot(proofItemExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, branch, err := ssz.Prove(proofItemExample, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf != root || len(branch) != 0 {
+		t.Errorf("Expected root %#x with an empty branch, received %#x with %d", root, leaf, len(branch))
+	}
+}
+
+func TestProve_BelowLeaf(t *testing.T) {
+	// Slot is a basic value, so nothing lies below its leaf.
+	if _, _, err := ssz.Prove(proofItemExample, 16); err == nil {
+		t.Error("Expected error proving below a basic leaf, received nil")
+	}
+	if _, _, err := ssz.Prove(proofItemExample, 0); err == nil {
+		t.Error("Expected error proving generalized index 0, received nil")
+	}
+}
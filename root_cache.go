@@ -0,0 +1,123 @@
+package ssz
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RootCache remembers the roots of the fields of the struct it is embedded in, so that
+// hashing the struct again only rehashes the fields marked as changed since:
+//  type BeaconState struct {
+//      ssz.RootCache
+//      Slot     uint64
+//      Balances []uint64 `ssz-max:"1099511627776"`
+//  }
+//
+//  state.Balances[3] += 10
+//  state.MarkDirty("balances")
+//  root, err := ssz.HashTreeRoot(state)
+// Fields are named as in GeneralizedIndex. A field changed without being marked keeps
+// its previous root, including fields of nested structs, which must be marked in every
+// enclosing struct. The roots are only remembered when hashing with SHA256 through a
+// pointer to the struct, and the same struct must not be hashed concurrently. A copy of
+// the struct shares its roots with the original, but each tracks its own changes. The
+// RootCache is not part of the value: it is not encoded and its zero value hashes every
+// field.
+type RootCache struct {
+	roots [][32]byte
+	dirty map[string]bool
+}
+
+var rootCacheType = reflect.TypeOf(RootCache{})
+
+// MarkDirty records that the named fields have changed, so that their roots are
+// computed again the next time the struct is hashed.
+func (r *RootCache) MarkDirty(names ...string) {
+	// The set is copied rather than updated in place, as copies of the struct share it.
+	dirty := make(map[string]bool, len(r.dirty)+len(names))
+	for name := range r.dirty {
+		dirty[name] = true
+	}
+	for _, name := range names {
+		dirty[normalizeFieldName(name)] = true
+	}
+	r.dirty = dirty
+}
+
+// Reset forgets the roots of all the fields.
+func (r *RootCache) Reset() {
+	r.roots = nil
+	r.dirty = nil
+}
+
+// rootCacheIndex returns the index of the RootCache field of the struct type typ.
+func rootCacheIndex(typ reflect.Type) (int, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).Type == rootCacheType {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// makeRootCacheHasher returns a hasher for structs with a RootCache at cacheIndex,
+// which reuses the roots of their clean fields.
+func makeRootCacheHasher(fields []field, cacheIndex int) (hasher, error) {
+	names := make([]string, len(fields))
+	known := make(map[string]bool, len(fields))
+	for i, f := range fields {
+		names[i] = normalizeFieldName(f.name)
+		known[names[i]] = true
+	}
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		var cache *RootCache
+		switch cacheVal := val.Field(cacheIndex); {
+		case c.hasher != SHA256:
+			cache = &RootCache{}
+		case cacheVal.CanAddr():
+			cache = cacheVal.Addr().Interface().(*RootCache)
+		default:
+			// The roots can still be read from a copy, but are not remembered.
+			cached := cacheVal.Interface().(RootCache)
+			cache = &cached
+		}
+		for name := range cache.dirty {
+			if !known[name] {
+				return [32]byte{}, fmt.Errorf("struct %v has no field %s marked as dirty", val.Type(), name)
+			}
+		}
+		reuse := len(cache.roots) == len(fields)
+		roots := make([][32]byte, len(fields))
+		start := c.mark()
+		for i, f := range fields {
+			if reuse && !cache.dirty[names[i]] {
+				roots[i] = cache.roots[i]
+			} else {
+				r, err := hashField(val, f, c)
+				if err != nil {
+					return [32]byte{}, err
+				}
+				roots[i] = r
+			}
+			c.appendRoot(roots[i])
+		}
+		// The roots are replaced rather than updated in place, as copies of the struct
+		// share them.
+		cache.roots = roots
+		cache.dirty = nil
+		return c.merkleize(start, uint64(len(fields)), true /* has limit */)
+	}
+	return hasher, nil
+}
+
+// hasRootCache reports whether typ is a struct with a RootCache, or a pointer to one.
+func hasRootCache(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := rootCacheIndex(typ)
+	return ok
+}
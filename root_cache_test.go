@@ -0,0 +1,177 @@
+package ssz_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type cachedState struct {
+	ssz.RootCache
+	Slot       uint64
+	Checkpoint testCheckpoint
+	Balances   []uint64 `ssz-max:"1099511627776"`
+}
+
+type uncachedState struct {
+	Slot       uint64
+	Checkpoint testCheckpoint
+	Balances   []uint64 `ssz-max:"1099511627776"`
+}
+
+func newCachedState(n int) *cachedState {
+	s := &cachedState{Slot: 1, Balances: make([]uint64, n)}
+	for i := range s.Balances {
+		s.Balances[i] = uint64(i)
+	}
+	return s
+}
+
+// uncachedRoot returns the root of the fields of s, computed from scratch.
+func uncachedRoot(t *testing.T, s *cachedState) [32]byte {
+	root, err := ssz.HashTreeRoot(uncachedState{Slot: s.Slot, Checkpoint: s.Checkpoint, Balances: s.Balances})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func checkCachedRoot(t *testing.T, s *cachedState, want [32]byte) {
+	t.Helper()
+	root, err := ssz.HashTreeRoot(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("HashTreeRoot() = %#x, want %#x", root, want)
+	}
+}
+
+func TestRootCache_NotPartOfValue(t *testing.T) {
+	s := newCachedState(4)
+	encoded, err := ssz.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ssz.Marshal(uncachedState{Slot: s.Slot, Balances: s.Balances})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %#x, want %#x", encoded, want)
+	}
+	checkCachedRoot(t, s, uncachedRoot(t, s))
+	decoded := &cachedState{}
+	if err := ssz.Unmarshal(encoded, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !ssz.DeepEqual(decoded, s) {
+		t.Errorf("Unmarshal() = %+v, want %+v", decoded, s)
+	}
+}
+
+func TestRootCache_MarkDirty(t *testing.T) {
+	s := newCachedState(100)
+	checkCachedRoot(t, s, uncachedRoot(t, s))
+
+	// Unmarked changes are not seen, as the roots of the fields are reused.
+	stale := uncachedRoot(t, s)
+	s.Balances[7] = 1000
+	checkCachedRoot(t, s, stale)
+
+	s.MarkDirty("balances")
+	s.Slot = 2
+	s.MarkDirty("Slot")
+	checkCachedRoot(t, s, uncachedRoot(t, s))
+
+	s.Checkpoint.Epoch = 9
+	s.MarkDirty("checkpoint")
+	checkCachedRoot(t, s, uncachedRoot(t, s))
+
+	s.Balances = append(s.Balances, 5)
+	s.Reset()
+	checkCachedRoot(t, s, uncachedRoot(t, s))
+
+	s.MarkDirty("balance")
+	if _, err := ssz.HashTreeRoot(s); err == nil {
+		t.Error("Expected error for a dirty field which does not exist")
+	}
+}
+
+func TestRootCache_Copies(t *testing.T) {
+	s := newCachedState(10)
+	checkCachedRoot(t, s, uncachedRoot(t, s))
+	original := uncachedRoot(t, s)
+
+	cp := *s
+	cp.Balances = append([]uint64{}, s.Balances...)
+	cp.Balances[0] = 42
+	cp.MarkDirty("balances")
+	checkCachedRoot(t, &cp, uncachedRoot(t, &cp))
+	checkCachedRoot(t, s, original)
+
+	// Hashing by value reads the roots without remembering new ones.
+	s.Slot = 3
+	s.MarkDirty("slot")
+	if root, err := ssz.HashTreeRoot(*s); err != nil || root != uncachedRoot(t, s) {
+		t.Errorf("HashTreeRoot() = %#x, %v, want %#x", root, err, uncachedRoot(t, s))
+	}
+	checkCachedRoot(t, s, uncachedRoot(t, s))
+}
+
+func TestRootCache_OtherHasher(t *testing.T) {
+	h := ssz.NewHasher(func(data []byte) [32]byte {
+		return ssz.SHA256.Hash(append([]byte{1}, data...))
+	})
+	s := newCachedState(10)
+	if _, err := ssz.HashTreeRoot(s); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ssz.HashTreeRootWithHasher(s, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ssz.HashTreeRootWithHasher(uncachedState{Slot: s.Slot, Balances: s.Balances}, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("HashTreeRootWithHasher() = %#x, want %#x", got, want)
+	}
+}
+
+func TestRootCache_SkippedBySize(t *testing.T) {
+	type fixed struct {
+		ssz.RootCache
+		Slot uint64
+	}
+	encoded, err := ssz.Marshal([]fixed{{Slot: 1}, {Slot: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fixed-size elements are not preceded by offsets.
+	if len(encoded) != 16 {
+		t.Errorf("Expected 16 bytes, received %d", len(encoded))
+	}
+	if !reflect.DeepEqual(encoded[8:], []byte{2, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Errorf("Unexpected encoding %#x", encoded)
+	}
+}
+
+func BenchmarkRootCache_ChangedSlot(b *testing.B) {
+	s := newCachedState(100000)
+	if _, err := ssz.HashTreeRoot(s); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		s.Slot++
+		s.MarkDirty("slot")
+		if _, err := ssz.HashTreeRoot(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
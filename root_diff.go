@@ -0,0 +1,222 @@
+package ssz
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// RootEqual reports whether a and b have the same hash tree root, which for values of
+// the same type means they have the same SSZ value. It is much cheaper than DeepEqual
+// for values whose roots are cached or remembered by a RootCache.
+func RootEqual(a, b interface{}) (bool, error) {
+	rootA, err := HashTreeRoot(a)
+	if err != nil {
+		return false, err
+	}
+	rootB, err := HashTreeRoot(b)
+	if err != nil {
+		return false, err
+	}
+	return rootA == rootB, nil
+}
+
+// RootDiff compares the Merkle trees of two values of the same type, and returns the
+// leaves in which they differ, such as the field holding a different state root. It
+// only descends into the subtrees whose roots differ, so finding a few differences
+// between two large states takes a number of steps logarithmic in their size, once
+// their trees are built. Basic values, byte slices and arrays, bitlists and unions
+// are leaves, while lists and vectors of other basic values report their differing
+// elements. Values are accepted as by NewView:
+//  diffs, err := ssz.RootDiff(ourState, theirState)
+//  if err != nil {
+//      return err
+//  }
+//  log.Printf("States differ:\n%s", ssz.FormatDiff(diffs))
+func RootDiff(a, b interface{}) ([]Difference, error) {
+	if a == nil || b == nil {
+		return nil, errors.New("untyped nil is not supported")
+	}
+	typ := reflect.TypeOf(a)
+	if reflect.TypeOf(b) != typ {
+		return nil, fmt.Errorf("cannot compare values of types %v and %v", typ, reflect.TypeOf(b))
+	}
+	viewA, err := NewView(a)
+	if err != nil {
+		return nil, err
+	}
+	viewB, err := NewView(b)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := viewA.HashTreeRoot(); err != nil {
+		return nil, err
+	}
+	if _, err := viewB.HashTreeRoot(); err != nil {
+		return nil, err
+	}
+	name := rootPathName(typ)
+	valA, valB := reflect.ValueOf(a), reflect.ValueOf(b)
+	for valA.Kind() == reflect.Ptr {
+		valA, valB = valA.Elem(), valB.Elem()
+	}
+	d := &differ{}
+	if err := d.rootDiff(name, valA, valB, viewA.typ, 0, viewA.root, viewB.root); err != nil {
+		return nil, err
+	}
+	return d.diffs, nil
+}
+
+// rootDiff adds the differences between a and b, hashed as typ into the trees rooted
+// at nodeA and nodeB. It classifies typ with kindOf, as View.build does.
+func (d *differ) rootDiff(path string, a, b reflect.Value, typ reflect.Type, capacity uint64, nodeA, nodeB *treeNode) error {
+	if nodeA.root == nodeB.root {
+		return nil
+	}
+	if typ.Kind() == reflect.Ptr {
+		if a.IsNil() || b.IsNil() {
+			d.add(path, a, b, false)
+			return nil
+		}
+		return d.rootDiff(path, a.Elem(), b.Elem(), typ.Elem(), capacity, nodeA, nodeB)
+	}
+	kind, ok := kindOf(typ, false)
+	switch {
+	case implements(typ, hashRootType) || ok && (kind == KindBasic || kind == KindUnion) || isBytes(typ):
+		// Byte lists and vectors, bitlists among them, are compared as a whole.
+		d.add(path, a, b, false)
+		return nil
+	case !ok:
+		return fmt.Errorf("type %v is not hashable", typ)
+	case kind == KindContainer:
+		fields, err := cachedStructFields(typ)
+		if err != nil {
+			return err
+		}
+		depth := treeDepth(uint64(len(fields)))
+		for _, i := range differingLeaves(nodeA, nodeB, depth, 0, uint64(len(fields)), nil) {
+			f := fields[i]
+			childA, childB, err := children(nodeA, nodeB, depth, i)
+			if err != nil {
+				return err
+			}
+			err = d.rootDiff(path+fieldSegment(f.name), a.Field(f.index), b.Field(f.index), f.typ, f.capacity, childA, childB)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return d.elementsRootDiff(path, a, b, typ, capacity, nodeA, nodeB)
+	}
+}
+
+// elementsRootDiff adds the differences between the elements of the lists or vectors
+// a and b. Elements past the end of the shorter list are compared with nil.
+func (d *differ) elementsRootDiff(path string, a, b reflect.Value, typ reflect.Type, capacity uint64, nodeA, nodeB *treeNode) error {
+	elem := typ.Elem()
+	n := a.Len()
+	if b.Len() < n {
+		n = b.Len()
+	}
+	// The elements of lists are the left subtree of their root, next to their length.
+	dataA, dataB := nodeA, nodeB
+	depthA := treeDepth(uint64(a.Len()))
+	depthB := depthA
+	if typ.Kind() == reflect.Slice {
+		dataA, dataB = nodeA.left, nodeB.left
+		depthA = treeDepth(listLimit(typ, capacity, uint64(a.Len())))
+		depthB = treeDepth(listLimit(typ, capacity, uint64(b.Len())))
+	}
+	if isPacked(elem) {
+		// Basic elements are packed into chunks, whose elements are compared by value.
+		perChunk := uint64(BytesPerChunk) / fixedTypeSize(elem)
+		if typ.Kind() == reflect.Array {
+			depthA = treeDepth((uint64(a.Len()) + perChunk - 1) / perChunk)
+			depthB = depthA
+		}
+		chunks := (uint64(n) + perChunk - 1) / perChunk
+		for _, c := range differingLeaves(dataA, dataB, depthA, 0, chunks, nil) {
+			for i := c * perChunk; i < (c+1)*perChunk && i < uint64(n); i++ {
+				if !basicValueEqual(a.Index(int(i)), b.Index(int(i))) {
+					d.add(path+indexSegment(int(i)), a.Index(int(i)), b.Index(int(i)), false)
+				}
+			}
+		}
+	} else {
+		var indices []uint64
+		if depthA == depthB {
+			indices = differingLeaves(dataA, dataB, depthA, 0, uint64(n), nil)
+		} else {
+			// Lists without a capacity are merkleized up to their length, so their trees
+			// only line up element by element.
+			for i := 0; i < n; i++ {
+				indices = append(indices, uint64(i))
+			}
+		}
+		for _, i := range indices {
+			childA, err := dataA.child(1<<depthA | i)
+			if err != nil {
+				return err
+			}
+			childB, err := dataB.child(1<<depthB | i)
+			if err != nil {
+				return err
+			}
+			if err := d.rootDiff(path+indexSegment(int(i)), a.Index(int(i)), b.Index(int(i)), elem, 0, childA, childB); err != nil {
+				return err
+			}
+		}
+	}
+	for i := n; i < a.Len(); i++ {
+		d.add(path+indexSegment(i), a.Index(i), reflect.Value{}, false)
+	}
+	for i := n; i < b.Len(); i++ {
+		d.add(path+indexSegment(i), reflect.Value{}, b.Index(i), false)
+	}
+	return nil
+}
+
+// differingLeaves appends to indices the indices of the leaves, out of the first count
+// at depth below a and b, whose roots differ, skipping the subtrees whose roots are
+// equal. base is the index of the first leaf below a and b.
+func differingLeaves(a, b *treeNode, depth uint64, base uint64, count uint64, indices []uint64) []uint64 {
+	if base >= count || a.root == b.root {
+		return indices
+	}
+	if depth == 0 || a.left == nil || b.left == nil {
+		return append(indices, base)
+	}
+	indices = differingLeaves(a.left, b.left, depth-1, base, count, indices)
+	return differingLeaves(a.right, b.right, depth-1, base+1<<(depth-1), count, indices)
+}
+
+// children returns the leaves at index i, at depth below a and b.
+func children(a, b *treeNode, depth uint64, i uint64) (*treeNode, *treeNode, error) {
+	childA, err := a.child(1<<depth | i)
+	if err != nil {
+		return nil, nil, err
+	}
+	childB, err := b.child(1<<depth | i)
+	if err != nil {
+		return nil, nil, err
+	}
+	return childA, childB, nil
+}
+
+// isBytes reports whether typ is a byte slice or array, which is compared as a whole.
+func isBytes(typ reflect.Type) bool {
+	return (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) && typ.Elem().Kind() == reflect.Uint8
+}
+
+// basicValueEqual compares two basic values of the same type packed into chunks.
+func basicValueEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Array:
+		return bytesValueEqual(a, b)
+	default:
+		return a.Uint() == b.Uint()
+	}
+}
@@ -0,0 +1,123 @@
+package ssz_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+// cloneViewState returns a deep copy of s, made by decoding its encoding.
+func cloneViewState(t testing.TB, s *viewState) *viewState {
+	encoded, err := ssz.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &viewState{}
+	if err := ssz.Unmarshal(encoded, c); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRootEqual(t *testing.T) {
+	a := newViewState(10)
+	b := cloneViewState(t, a)
+	if equal, err := ssz.RootEqual(a, b); err != nil || !equal {
+		t.Errorf("RootEqual() = %v, %v, want true", equal, err)
+	}
+	b.Balances[3]++
+	if equal, err := ssz.RootEqual(a, b); err != nil || equal {
+		t.Errorf("RootEqual() = %v, %v, want false", equal, err)
+	}
+}
+
+func TestRootDiff(t *testing.T) {
+	a := newViewState(40)
+	b := cloneViewState(t, a)
+	b.Slot = 6
+	b.Validators[12].Slashed = true
+	b.Balances[33] = 1
+	b.Roots[5][1] = 1
+	b.Participation[7] = 0xff
+	b.Total = big.NewInt(1001)
+	b.Validators = append(b.Validators, b.Validators[0])
+	want := []ssz.Difference{
+		{Path: "viewState.Slot", A: uint64(5), B: uint64(6)},
+		{Path: "viewState.Roots[5]", A: a.Roots[5], B: b.Roots[5]},
+		{Path: "viewState.Validators[12].Slashed", A: false, B: true},
+		{Path: "viewState.Validators[40]", A: nil, B: b.Validators[40]},
+		{Path: "viewState.Balances[33]", A: uint64(330), B: uint64(1)},
+		{Path: "viewState.Participation", A: a.Participation, B: b.Participation},
+		{Path: "viewState.Total", A: a.Total, B: b.Total},
+	}
+	diffs, err := ssz.RootDiff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("RootDiff() =\n%s\nwant\n%s", ssz.FormatDiff(diffs), ssz.FormatDiff(want))
+	}
+	if diffs, err := ssz.RootDiff(a, cloneViewState(t, a)); err != nil || len(diffs) != 0 {
+		t.Errorf("RootDiff() of equal values = %v, %v, want no differences", diffs, err)
+	}
+}
+
+func TestRootDiff_MatchesDiff(t *testing.T) {
+	a := newViewState(100)
+	b := cloneViewState(t, a)
+	for _, i := range []int{0, 31, 32, 63, 99} {
+		b.Validators[i].Balance++
+		b.Balances[i] = 0
+	}
+	b.Justified.Epoch = 2
+	b.Fork.Root[31] = 1
+	b.Payload.Value = uint64(8)
+	b.Balances = b.Balances[:98]
+	diffs, err := ssz.RootDiff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, d := range ssz.Diff(a, b) {
+		if !d.Equivalent {
+			want = append(want, d.Path)
+		}
+	}
+	var got []string
+	for _, d := range diffs {
+		got = append(got, d.Path)
+	}
+	// Diff descends into unions, whose roots are leaves of the tree.
+	for i, path := range want {
+		if path == "viewState.Payload.Value" {
+			want[i] = "viewState.Payload"
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RootDiff() paths = %v, want %v", got, want)
+	}
+}
+
+func TestRootDiff_Errors(t *testing.T) {
+	if _, err := ssz.RootDiff(newViewState(1), testCheckpoint{}); err == nil {
+		t.Error("Expected error comparing values of different types")
+	}
+	if _, err := ssz.RootDiff(nil, nil); err == nil {
+		t.Error("Expected error comparing untyped nils")
+	}
+}
+
+func BenchmarkRootDiff_OneBalance(b *testing.B) {
+	a := newViewState(1024)
+	c := cloneViewState(b, a)
+	c.Balances[1000]++
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := ssz.RootDiff(a, c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package ssz
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strings"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// Kind is the SSZ kind of a type, such as a list or a container.
+type Kind int
+
+// The SSZ kinds described by a Schema.
+const (
+	// KindBasic is a boolean or an unsigned integer.
+	KindBasic Kind = iota
+	// KindVector is a fixed number of elements, such as a byte array.
+	KindVector
+	// KindList is a variable number of elements, up to a limit.
+	KindList
+	// KindBitvector is a fixed number of bits.
+	KindBitvector
+	// KindBitlist is a variable number of bits, up to a limit.
+	KindBitlist
+	// KindContainer is a struct.
+	KindContainer
+	// KindUnion is one of several variants, chosen by a selector.
+	KindUnion
+)
+
+var kindNames = []string{"basic", "vector", "list", "bitvector", "bitlist", "container", "union"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// kindOf returns the SSZ kind the values of typ are merkleized as, and false if they
+// cannot be. makeHasher picks hashers by it, and SchemaOf, proofs, views and root
+// differences classify types with it too, so that they describe the trees hashers
+// build. Pointers are left to callers, as nil ones hash to a zero chunk. Bitlists
+// are only bitlists as struct fields, where field is set, and lists of bytes
+// elsewhere.
+func kindOf(typ reflect.Type, field bool) (Kind, bool) {
+	kind := typ.Kind()
+	switch {
+	case typ == unionType:
+		return KindUnion, true
+	case isBasicType(kind) || isBasicUintType(typ):
+		return KindBasic, true
+	case field && typ == bitlistType:
+		return KindBitlist, true
+	case kind == reflect.Array:
+		return KindVector, true
+	case kind == reflect.Slice:
+		return KindList, true
+	case kind == reflect.Struct:
+		return KindContainer, true
+	default:
+		return 0, false
+	}
+}
+
+// isPacked reports whether elements of type elem are packed together into chunks,
+// rather than merkleized into a chunk each.
+func isPacked(elem reflect.Type) bool {
+	kind, ok := kindOf(elem, false)
+	return ok && kind == KindBasic
+}
+
+// UnboundedSize is the maximum encoded size of the values of a schema which have no
+// limit, such as lists without an ssz-max tag.
+const UnboundedSize = math.MaxUint64
+
+// bitvectorTypes holds the number of bits of the bitvectors of go-bitfield, which
+// are encoded as byte vectors by fields sized with an ssz-size tag.
+var bitvectorTypes = map[reflect.Type]uint64{
+	reflect.TypeOf(bitfield.Bitvector4{}):   4,
+	reflect.TypeOf(bitfield.Bitvector8{}):   8,
+	reflect.TypeOf(bitfield.Bitvector32{}):  32,
+	reflect.TypeOf(bitfield.Bitvector64{}):  64,
+	reflect.TypeOf(bitfield.Bitvector128{}): 128,
+	reflect.TypeOf(bitfield.Bitvector256{}): 256,
+	reflect.TypeOf(bitfield.Bitvector512{}): 512,
+}
+
+// Schema describes how the values of a type are encoded and merkleized, as the
+// marshaler and hasher of the type see it:
+//  schema, err := ssz.SchemaOf(reflect.TypeOf(pb.BeaconState{}))
+//  if err != nil {
+//      return err
+//  }
+//  for _, f := range schema.Fields {
+//      fmt.Printf("%s %v fixed=%t size=%d..%d\n", f.Name, f.Schema, f.Schema.Fixed, f.Schema.MinSize, f.Schema.MaxSize)
+//  }
+// Types implementing Marshaler or HashRoot are described by their Go types, the same
+// way offsets are laid out around them.
+type Schema struct {
+	Kind Kind
+	// Type is the Go type described, with pointers dereferenced. Struct fields are
+	// described by the types their ssz-size and ssz-type tags give them.
+	Type reflect.Type
+	// Length is the number of elements of a vector, or of bits of a bitvector.
+	Length uint64
+	// Limit is the maximum number of elements of a list, or of bits of a bitlist, set
+	// by an ssz-max tag. It is zero for lists without one, which are merkleized up to
+	// their length.
+	Limit uint64
+	// Elem describes the elements of vectors and lists.
+	Elem *Schema
+	// Fields describes the fields of containers, in order.
+	Fields []SchemaField
+	// Variants describes the variants of unions declared with an ssz-union tag, in
+	// selector order, with nil for a None variant.
+	Variants []*Schema
+	// Fixed is set for types whose values all have the same encoded size.
+	Fixed bool
+	// MinSize and MaxSize bound the encoded size of values, in bytes. MaxSize is
+	// UnboundedSize when values have no maximum size.
+	MinSize uint64
+	MaxSize uint64
+	// ChunkCount is the number of chunks the value is merkleized into, before the
+	// length of lists and bitlists is mixed in. It is zero for lists without a limit,
+	// and one for unions, whose root is the root of their value.
+	ChunkCount uint64
+}
+
+// SchemaField is a field of a container.
+type SchemaField struct {
+	Name   string
+	Schema *Schema
+}
+
+// String formats the schema in the notation of the specification, such as
+// List[Validator, 1099511627776] or Vector[uint8, 32].
+func (s *Schema) String() string {
+	switch s.Kind {
+	case KindBasic:
+		return basicTypeName(s.Type)
+	case KindVector:
+		return fmt.Sprintf("Vector[%v, %d]", s.Elem, s.Length)
+	case KindList:
+		return fmt.Sprintf("List[%v, %d]", s.Elem, s.Limit)
+	case KindBitvector:
+		return fmt.Sprintf("Bitvector[%d]", s.Length)
+	case KindBitlist:
+		return fmt.Sprintf("Bitlist[%d]", s.Limit)
+	case KindUnion:
+		names := make([]string, len(s.Variants))
+		for i, v := range s.Variants {
+			if v == nil {
+				names[i] = noneUnionVariant
+			} else {
+				names[i] = v.String()
+			}
+		}
+		return fmt.Sprintf("Union[%s]", strings.Join(names, ", "))
+	default:
+		if s.Type.Name() != "" {
+			return s.Type.Name()
+		}
+		return "Container"
+	}
+}
+
+func basicTypeName(typ reflect.Type) string {
+	switch {
+	case typ.Kind() == reflect.Bool:
+		return "bool"
+	case typ == uint128Type:
+		return "uint128"
+	case typ == uint256Type:
+		return "uint256"
+	default:
+		return fmt.Sprintf("uint%d", typ.Bits())
+	}
+}
+
+// SchemaOf returns the schema of the values of typ, which is built from the same
+// struct tags and rules as its marshaler and hasher. It returns an error for types
+// which cannot be encoded, and for recursive types, whose schema is infinite.
+func SchemaOf(typ reflect.Type) (*Schema, error) {
+	if typ == nil {
+		return nil, errors.New("untyped nil is not supported")
+	}
+	if typ == bigIntType {
+		return nil, errors.New("big integers need an ssz-type tag on the field holding them")
+	}
+	if _, err := cachedSSZUtils(typ); err != nil {
+		return nil, fmt.Errorf("could not get ssz utils for type: %v: %v", typ, err)
+	}
+	b := &schemaBuilder{inProgress: make(map[reflect.Type]bool)}
+	return b.schemaOf(typ, 0, false /* field */)
+}
+
+// schemaBuilder builds schemas, keeping track of the structs being described to
+// detect recursive types.
+type schemaBuilder struct {
+	inProgress map[reflect.Type]bool
+}
+
+// schemaOf returns the schema of typ, the type of a struct field if field is set,
+// whose ssz-max tag, if any, sets its capacity.
+func (b *schemaBuilder) schemaOf(typ reflect.Type, capacity uint64, field bool) (*Schema, error) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	kind, ok := kindOf(typ, field)
+	if !ok {
+		return nil, fmt.Errorf("type %v is not supported", typ)
+	}
+	s := &Schema{Kind: kind, Type: typ}
+	switch kind {
+	case KindUnion:
+		return b.unionSchema(typ, nil)
+	case KindBasic:
+		s.Fixed = true
+		s.MinSize = fixedTypeSize(typ)
+		s.MaxSize = s.MinSize
+		s.ChunkCount = 1
+	case KindBitlist:
+		s.Limit = capacity
+		// Bitlists end with a length bit, so even empty ones take a byte.
+		s.MinSize = 1
+		s.MaxSize = UnboundedSize
+		if capacity > 0 {
+			s.MaxSize = capacity/8 + 1
+			s.ChunkCount = (capacity + 255) / 256
+		}
+	case KindVector, KindList:
+		elem, err := b.schemaOf(typ.Elem(), 0, false /* field */)
+		if err != nil {
+			return nil, err
+		}
+		s.Elem = elem
+		elemSize := elem.MaxSize
+		if !elem.Fixed {
+			elemSize = addSize(elemSize, BytesPerLengthOffset)
+		}
+		if kind == KindVector {
+			s.Length = uint64(typ.Len())
+			s.Fixed = elem.Fixed
+			s.MaxSize = mulSize(s.Length, elemSize)
+			s.MinSize = s.MaxSize
+			if !elem.Fixed {
+				s.MinSize = mulSize(s.Length, addSize(elem.MinSize, BytesPerLengthOffset))
+			}
+			s.ChunkCount = chunkCount(elem, s.Length)
+			break
+		}
+		s.Limit = capacity
+		s.MaxSize = UnboundedSize
+		if capacity > 0 {
+			s.MaxSize = mulSize(capacity, elemSize)
+			s.ChunkCount = chunkCount(elem, capacity)
+		}
+	default:
+		return b.containerSchema(typ)
+	}
+	return s, nil
+}
+
+// chunkCount returns the number of chunks n elements described by elem are packed
+// or merkleized into.
+func chunkCount(elem *Schema, n uint64) uint64 {
+	if elem.Kind != KindBasic {
+		return n
+	}
+	chunkSize := uint64(BytesPerChunk)
+	return addSize(mulSize(n, elem.MaxSize), chunkSize-1) / chunkSize
+}
+
+func (b *schemaBuilder) containerSchema(typ reflect.Type) (*Schema, error) {
+	if b.inProgress[typ] {
+		return nil, fmt.Errorf("type %v is recursive", typ)
+	}
+	b.inProgress[typ] = true
+	defer delete(b.inProgress, typ)
+	fields, err := cachedStructFields(typ)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{
+		Kind:       KindContainer,
+		Type:       typ,
+		Fields:     make([]SchemaField, len(fields)),
+		Fixed:      true,
+		ChunkCount: uint64(len(fields)),
+	}
+	for i, f := range fields {
+		fieldSchema, err := b.fieldSchema(typ.Field(f.index), f)
+		if err != nil {
+			return nil, withPath(err, fieldSegment(f.name), 0)
+		}
+		s.Fields[i] = SchemaField{Name: f.name, Schema: fieldSchema}
+		if fieldSchema.Fixed {
+			s.MinSize = addSize(s.MinSize, fieldSchema.MinSize)
+			s.MaxSize = addSize(s.MaxSize, fieldSchema.MaxSize)
+			continue
+		}
+		// Variable-size fields are encoded as an offset, followed by their content
+		// after the fixed-size part.
+		s.Fixed = false
+		s.MinSize = addSize(s.MinSize, addSize(fieldSchema.MinSize, BytesPerLengthOffset))
+		s.MaxSize = addSize(s.MaxSize, addSize(fieldSchema.MaxSize, BytesPerLengthOffset))
+	}
+	return s, nil
+}
+
+// fieldSchema returns the schema of the struct field sf, whose tags were parsed
+// into f.
+func (b *schemaBuilder) fieldSchema(sf reflect.StructField, f field) (*Schema, error) {
+	if tag, ok := sf.Tag.Lookup("ssz-union"); ok {
+		variants, err := parseUnionVariants(tag)
+		if err != nil {
+			return nil, err
+		}
+		return b.unionSchema(f.typ, variants)
+	}
+	if bitLen, ok := bitvectorTypes[sf.Type]; ok && f.typ.Kind() == reflect.Array {
+		size := fixedTypeSize(f.typ)
+		return &Schema{
+			Kind:       KindBitvector,
+			Type:       f.typ,
+			Length:     bitLen,
+			Fixed:      true,
+			MinSize:    size,
+			MaxSize:    size,
+			ChunkCount: (bitLen + 255) / 256,
+		}, nil
+	}
+	return b.schemaOf(f.typ, f.capacity, true /* field */)
+}
+
+// unionSchema returns the schema of a union with the given variants, or of a union
+// whose variants are unknown if variants is nil.
+func (b *schemaBuilder) unionSchema(typ reflect.Type, variants []reflect.Type) (*Schema, error) {
+	s := &Schema{
+		Kind:       KindUnion,
+		Type:       typ,
+		MinSize:    1,
+		MaxSize:    UnboundedSize,
+		ChunkCount: 1,
+	}
+	if variants == nil {
+		return s, nil
+	}
+	s.Variants = make([]*Schema, len(variants))
+	minSize, maxSize := uint64(UnboundedSize), uint64(0)
+	for i, v := range variants {
+		if v == nil {
+			minSize = 0
+			continue
+		}
+		variant, err := b.schemaOf(v, 0, false /* field */)
+		if err != nil {
+			return nil, err
+		}
+		s.Variants[i] = variant
+		if variant.MinSize < minSize {
+			minSize = variant.MinSize
+		}
+		if variant.MaxSize > maxSize {
+			maxSize = variant.MaxSize
+		}
+	}
+	// The selector takes one byte before the value.
+	s.MinSize = addSize(minSize, 1)
+	s.MaxSize = addSize(maxSize, 1)
+	return s, nil
+}
+
+// addSize adds two sizes, saturating at UnboundedSize.
+func addSize(a, b uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 {
+		return UnboundedSize
+	}
+	return sum
+}
+
+// mulSize multiplies two sizes, saturating at UnboundedSize.
+func mulSize(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	if hi != 0 {
+		return UnboundedSize
+	}
+	return lo
+}
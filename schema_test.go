@@ -0,0 +1,166 @@
+package ssz_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type schemaState struct {
+	Slot          uint64
+	Roots         [][]byte            `ssz-size:"4,32"`
+	Validators    []*testValidator    `ssz-max:"16"`
+	Bits          bitfield.Bitlist    `ssz-max:"2048"`
+	Justification bitfield.Bitvector4 `ssz-size:"1"`
+	Total         *big.Int            `ssz-type:"uint256"`
+	Payload       ssz.Union           `ssz-union:"None,uint64,unionCheckpoint"`
+	Extra         [][]uint64          `ssz-size:"?,3"`
+	Cache         ssz.RootCache
+}
+
+type schemaNode struct {
+	Value    uint64
+	Children []schemaNode `ssz-max:"4"`
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := ssz.SchemaOf(reflect.TypeOf(&schemaState{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.Kind != ssz.KindContainer || schema.Type != reflect.TypeOf(schemaState{}) {
+		t.Errorf("SchemaOf() = %v of type %v, want a container of type schemaState", schema.Kind, schema.Type)
+	}
+	if schema.Fixed || schema.ChunkCount != 8 {
+		t.Errorf("Fixed = %t, ChunkCount = %d, want false, 8", schema.Fixed, schema.ChunkCount)
+	}
+	want := []struct {
+		name       string
+		schema     string
+		fixed      bool
+		minSize    uint64
+		maxSize    uint64
+		chunkCount uint64
+	}{
+		{"Slot", "uint64", true, 8, 8, 1},
+		{"Roots", "Vector[Vector[uint8, 32], 4]", true, 128, 128, 4},
+		{"Validators", "List[testValidator, 16]", false, 0, 16 * 57, 16},
+		{"Bits", "Bitlist[2048]", false, 1, 257, 8},
+		{"Justification", "Bitvector[4]", true, 1, 1, 1},
+		{"Total", "uint256", true, 32, 32, 1},
+		{"Payload", "Union[None, uint64, unionCheckpoint]", false, 1, 41, 1},
+		{"Extra", "List[Vector[uint64, 3], 0]", false, 0, ssz.UnboundedSize, 0},
+	}
+	if len(schema.Fields) != len(want) {
+		t.Fatalf("SchemaOf() has %d fields, want %d", len(schema.Fields), len(want))
+	}
+	for i, w := range want {
+		f := schema.Fields[i]
+		if f.Name != w.name || f.Schema.String() != w.schema {
+			t.Errorf("Fields[%d] = %s %v, want %s %s", i, f.Name, f.Schema, w.name, w.schema)
+			continue
+		}
+		s := f.Schema
+		if s.Fixed != w.fixed || s.MinSize != w.minSize || s.MaxSize != w.maxSize || s.ChunkCount != w.chunkCount {
+			t.Errorf("%s: Fixed = %t, MinSize = %d, MaxSize = %d, ChunkCount = %d, want %t, %d, %d, %d",
+				f.Name, s.Fixed, s.MinSize, s.MaxSize, s.ChunkCount, w.fixed, w.minSize, w.maxSize, w.chunkCount)
+		}
+	}
+	if limit := schema.Fields[2].Schema.Limit; limit != 16 {
+		t.Errorf("Validators limit = %d, want 16", limit)
+	}
+	if length := schema.Fields[4].Schema.Length; length != 4 {
+		t.Errorf("Justification length = %d, want 4", length)
+	}
+}
+
+func TestSchemaOf_BitlistsOutsideFields(t *testing.T) {
+	// Bitlists are only hashed as bitlists by struct fields, and as byte lists elsewhere.
+	schema, err := ssz.SchemaOf(reflect.TypeOf([]bitfield.Bitlist{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := schema.String(); s != "List[List[uint8, 0], 0]" {
+		t.Errorf("SchemaOf() = %s, want List[List[uint8, 0], 0]", s)
+	}
+}
+
+func TestSchemaOf_SizesMatchEncoding(t *testing.T) {
+	full := &schemaState{
+		Roots:         make([][]byte, 4),
+		Bits:          bitfield.NewBitlist(2048),
+		Justification: bitfield.NewBitvector4(),
+		Total:         big.NewInt(0),
+		Payload:       ssz.Union{Selector: 2, Value: unionCheckpoint{}},
+	}
+	for i := range full.Roots {
+		full.Roots[i] = make([]byte, 32)
+	}
+	for i := 0; i < 16; i++ {
+		full.Validators = append(full.Validators, &testValidator{Pubkey: make([]byte, 48)})
+	}
+	empty := &schemaState{
+		Roots:         full.Roots,
+		Bits:          bitfield.NewBitlist(0),
+		Justification: bitfield.NewBitvector4(),
+		Total:         big.NewInt(0),
+	}
+	schema, err := ssz.SchemaOf(reflect.TypeOf(full))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := ssz.Marshal(empty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(len(encoded)) != schema.MinSize {
+		t.Errorf("Encoded size of the smallest value = %d, want MinSize %d", len(encoded), schema.MinSize)
+	}
+	// Extra has no limit, so the largest value is measured without it.
+	schema.Fields = schema.Fields[:len(schema.Fields)-1]
+	encoded, err = ssz.Marshal(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxSize := uint64(0)
+	for _, f := range schema.Fields {
+		maxSize += f.Schema.MaxSize
+		if !f.Schema.Fixed {
+			maxSize += ssz.BytesPerLengthOffset
+		}
+	}
+	if uint64(len(encoded)) != maxSize+ssz.BytesPerLengthOffset {
+		t.Errorf("Encoded size of the largest value = %d, want %d", len(encoded), maxSize+ssz.BytesPerLengthOffset)
+	}
+}
+
+func TestSchemaOf_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  reflect.Type
+	}{
+		{"Nil", nil},
+		{"Int", reflect.TypeOf(int(0))},
+		{"UntaggedBigInt", reflect.TypeOf(big.NewInt(0))},
+		{"Recursive", reflect.TypeOf(schemaNode{})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if schema, err := ssz.SchemaOf(tt.typ); err == nil {
+				t.Errorf("SchemaOf() = %v, want an error", schema)
+			}
+		})
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	if s := ssz.KindBitlist.String(); s != "bitlist" {
+		t.Errorf("KindBitlist.String() = %q, want bitlist", s)
+	}
+	if s := ssz.Kind(42).String(); s != "Kind(42)" {
+		t.Errorf("Kind(42).String() = %q, want Kind(42)", s)
+	}
+}
@@ -6,42 +6,151 @@ import (
 	"reflect"
 )
 
-// SigningRoot truncates the last property of the struct passed in
-// and returns its tree hash. This is done because the last property
-// usually contains the signature that which this data is the root for.
+// forkData identifies a fork of a chain, and is hashed into the domains of signatures
+// so that they are not valid on other forks.
+type forkData struct {
+	CurrentVersion        [4]byte
+	GenesisValidatorsRoot [32]byte
+}
+
+// signingData binds the root of an object to the domain it is signed in.
+type signingData struct {
+	ObjectRoot [32]byte
+	Domain     [32]byte
+}
+
+// ComputeDomain returns the domain of the signatures of the given type on the fork
+// with the given version and genesis validators root, as compute_domain does in the
+// specification. The domain is the type followed by the first 28 bytes of the root
+// of the fork data.
+func ComputeDomain(domainType [4]byte, forkVersion [4]byte, genesisValidatorsRoot [32]byte) ([32]byte, error) {
+	forkDataRoot, err := HashTreeRootWithHasher(forkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}, SHA256)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	var domain [32]byte
+	copy(domain[:4], domainType[:])
+	copy(domain[4:], forkDataRoot[:28])
+	return domain, nil
+}
+
+// ComputeSigningRoot returns the root signed to sign val in domain, as
+// compute_signing_root does in the specification. It is the root of the SigningData
+// of the root of val and the domain:
+//  domain, err := ssz.ComputeDomain(domainBeaconProposer, fork.CurrentVersion, genesisValidatorsRoot)
+//  if err != nil {
+//      return fmt.Errorf("failed to compute domain: %v", err)
+//  }
+//  root, err := ssz.ComputeSigningRoot(block, domain)
+// Roots are always computed with SHA256, whatever Hasher is set by SetHasher.
+func ComputeSigningRoot(val interface{}, domain [32]byte) ([32]byte, error) {
+	objectRoot, err := HashTreeRootWithHasher(val, SHA256)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return HashTreeRootWithHasher(signingData{
+		ObjectRoot: objectRoot,
+		Domain:     domain,
+	}, SHA256)
+}
+
+// SigningRoot returns the tree hash of the struct passed in without its signature,
+// which is the data the signature is intended to represent. The signature is made of
+// the fields tagged `ssz:"signature"`, or is the last field of structs without such tags.
+//
+// Deprecated: current specifications sign the root of the whole object, mixed with
+// the domain of the signature, which ComputeSigningRoot computes.
 func SigningRoot(val interface{}) ([32]byte, error) {
+	structVal, err := structValue(val)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	fields, err := cachedStructFields(structVal.Type())
+	if err != nil {
+		return [32]byte{}, err
+	}
+	signed := make([]field, 0, len(fields))
+	for _, f := range fields {
+		if !f.signature {
+			signed = append(signed, f)
+		}
+	}
+	if len(signed) == len(fields) {
+		// Without tags, the signature is assumed to be the last field.
+		if len(fields) == 0 {
+			return [32]byte{}, fmt.Errorf("struct %v has no signature field", structVal.Type())
+		}
+		signed = fields[:len(fields)-1]
+	}
+	return hashFields(structVal, signed)
+}
+
+// HashTreeRootExcluding returns the tree hash of the struct passed in, or of the struct
+// it points to, as if the named fields were not part of it. Fields are named as in
+// GeneralizedIndex:
+//  root, err := ssz.HashTreeRootExcluding(header, "state_root")
+func HashTreeRootExcluding(val interface{}, fieldNames ...string) ([32]byte, error) {
+	structVal, err := structValue(val)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	fields, err := cachedStructFields(structVal.Type())
+	if err != nil {
+		return [32]byte{}, err
+	}
+	excluded := make(map[int]bool, len(fieldNames))
+	for _, name := range fieldNames {
+		i, ok := fieldIndex(fields, name)
+		if !ok {
+			return [32]byte{}, fmt.Errorf("struct %v has no field %s", structVal.Type(), name)
+		}
+		excluded[i] = true
+	}
+	included := make([]field, 0, len(fields))
+	for i, f := range fields {
+		if !excluded[i] {
+			included = append(included, f)
+		}
+	}
+	return hashFields(structVal, included)
+}
+
+// structValue returns the struct val, or the struct val points to.
+func structValue(val interface{}) (reflect.Value, error) {
 	valObj := reflect.ValueOf(val)
 	kind := valObj.Kind()
 
 	switch {
 	case kind == reflect.Struct:
-		return truncateAndHash(valObj)
+		return valObj, nil
 	case kind == reflect.Ptr:
 		if valObj.IsNil() {
-			return [32]byte{}, errors.New("nil pointer given")
+			return reflect.Value{}, errors.New("nil pointer given")
 		}
 		deRefVal := valObj.Elem()
 		if deRefVal.Kind() != reflect.Struct {
-			return [32]byte{}, errors.New("invalid type")
+			return reflect.Value{}, errors.New("invalid type")
 		}
-		return truncateAndHash(deRefVal)
+		return deRefVal, nil
 	default:
-		return [32]byte{}, fmt.Errorf("given object is neither a struct or a pointer but is %v", kind)
+		return reflect.Value{}, fmt.Errorf("given object is neither a struct or a pointer but is %v", kind)
 	}
 }
 
-func truncateAndHash(val reflect.Value) ([32]byte, error) {
-	truncated, err := truncateLast(val.Type())
+// hashFields returns the root of the struct val made of fields only.
+func hashFields(val reflect.Value, fields []field) ([32]byte, error) {
+	hasher, err := makeFieldsHasher(fields)
 	if err != nil {
 		return [32]byte{}, err
 	}
-	hasher, err := makeFieldsHasher(truncated)
+	c := newHashContext(currentHasher())
+	defer c.release()
+	output, err := hasher(val, 0, c)
 	if err != nil {
-		return [32]byte{}, err
-	}
-	output, err := hasher(val, 0)
-	if err != nil {
-		return [32]byte{}, err
+		return [32]byte{}, newHashError(err, val.Type())
 	}
 	return output, nil
 }
@@ -2,6 +2,7 @@ package ssz
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"testing"
 )
 
@@ -87,3 +88,113 @@ func TestSigningRoot(t *testing.T) {
 		}
 	}
 }
+
+func TestComputeDomain(t *testing.T) {
+	// The domain of deposits, which are signed on the genesis fork of any chain.
+	want := [32]byte{
+		0x03, 0x00, 0x00, 0x00, 0xf5, 0xa5, 0xfd, 0x42, 0xd1, 0x6a, 0x20, 0x30, 0x27, 0x98, 0xef, 0x6e,
+		0xd3, 0x09, 0x97, 0x9b, 0x43, 0x00, 0x3d, 0x23, 0x20, 0xd9, 0xf0, 0xe8, 0xea, 0x98, 0x31, 0xa9,
+	}
+	domain, err := ComputeDomain([4]byte{3}, [4]byte{}, [32]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domain != want {
+		t.Errorf("ComputeDomain() = %#x, want %#x", domain, want)
+	}
+}
+
+func TestComputeSigningRoot(t *testing.T) {
+	val := &truncateSignatureCase{Slot: 20, Signature: []byte("TESTING")}
+	domain := [32]byte{1, 2, 3}
+	objectRoot, err := HashTreeRoot(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sha256.Sum256(append(objectRoot[:], domain[:]...))
+	root, err := ComputeSigningRoot(val, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("ComputeSigningRoot() = %#x, want %#x", root, want)
+	}
+	// Signing roots are computed with SHA-256 whatever the default Hasher is.
+	SetHasher(NewHasher(func(data []byte) [32]byte { return [32]byte{} }))
+	defer SetHasher(SHA256)
+	root, err = ComputeSigningRoot(val, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("ComputeSigningRoot() with another default Hasher = %#x, want %#x", root, want)
+	}
+}
+
+type taggedSignatureCase struct {
+	Signature []byte `ssz:"signature"`
+	Slot      uint64
+	StateRoot []byte
+}
+
+type unsignedCase struct {
+	Slot      uint64
+	StateRoot []byte
+}
+
+func TestSigningRoot_SignatureTag(t *testing.T) {
+	want, err := HashTreeRoot(unsignedCase{Slot: 5, StateRoot: []byte("MATTERS")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, signature := range [][]byte{[]byte("DOESNT"), []byte("MATTER")} {
+		root, err := SigningRoot(&taggedSignatureCase{Signature: signature, Slot: 5, StateRoot: []byte("MATTERS")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if root != want {
+			t.Errorf("SigningRoot() = %#x, want %#x", root, want)
+		}
+	}
+}
+
+func TestSigningRoot_EmptyStruct(t *testing.T) {
+	if _, err := SigningRoot(struct{}{}); err == nil {
+		t.Error("Expected error for a struct without a signature")
+	}
+}
+
+func TestHashTreeRootExcluding(t *testing.T) {
+	val := &truncateLastCase{Slot: 5, StateRoot: []byte("EXCLUDED"), TruncatedField: []byte("INCLUDED")}
+	want, err := HashTreeRoot(struct {
+		Slot           uint64
+		TruncatedField []byte
+	}{Slot: 5, TruncatedField: []byte("INCLUDED")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := HashTreeRootExcluding(val, "state_root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("HashTreeRootExcluding() = %#x, want %#x", root, want)
+	}
+
+	if want, err = HashTreeRoot(val); err != nil {
+		t.Fatal(err)
+	}
+	if root, err = HashTreeRootExcluding(*val); err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("HashTreeRootExcluding() without fields = %#x, want %#x", root, want)
+	}
+
+	if _, err := HashTreeRootExcluding(val, "signature"); err == nil {
+		t.Error("Expected error excluding a field which does not exist")
+	}
+	if _, err := HashTreeRootExcluding([]uint64{1}); err == nil {
+		t.Error("Expected error for a value which is not a struct")
+	}
+}
@@ -0,0 +1,3998 @@
+// Code generated by sszgen. DO NOT EDIT.
+// source: types.go
+
+package generated
+
+import (
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+// MarshalSSZ ssz marshals the MinimalFork object.
+func (m *MinimalFork) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalFork object and appends it to buf.
+func (m *MinimalFork) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalFork)
+	}
+	dst = buf
+
+	// Field (0) 'PreviousVersion'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.PreviousVersion, 4); err != nil {
+		return nil, err
+	}
+
+	// Field (1) 'CurrentVersion'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.CurrentVersion, 4); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'Epoch'
+	dst = ssz.MarshalUint64(dst, m.Epoch)
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalFork object.
+func (m *MinimalFork) SizeSSZ() (size int) {
+	size = 16
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalFork object.
+func (m *MinimalFork) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 16 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'PreviousVersion'
+	m.PreviousVersion = append(m.PreviousVersion[:0], buf[0:4]...)
+
+	// Field (1) 'CurrentVersion'
+	m.CurrentVersion = append(m.CurrentVersion[:0], buf[4:8]...)
+
+	// Field (2) 'Epoch'
+	m.Epoch = ssz.UnmarshalUint64(buf[8:16])
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalFork object.
+func (m *MinimalFork) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalFork)
+	}
+	var err error
+	roots := make([][]byte, 0, 3)
+
+	// Field (0) 'PreviousVersion'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.PreviousVersion, 4); err != nil {
+			return [32]byte{}, err
+		}
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'CurrentVersion'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.CurrentVersion, 4); err != nil {
+			return [32]byte{}, err
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Epoch'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Epoch)
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+	return ssz.Merkleize(roots, 3)
+}
+
+// MarshalSSZ ssz marshals the MinimalCheckpoint object.
+func (m *MinimalCheckpoint) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalCheckpoint object and appends it to buf.
+func (m *MinimalCheckpoint) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalCheckpoint)
+	}
+	dst = buf
+
+	// Field (0) 'Epoch'
+	dst = ssz.MarshalUint64(dst, m.Epoch)
+
+	// Field (1) 'Root'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Root, 32); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalCheckpoint object.
+func (m *MinimalCheckpoint) SizeSSZ() (size int) {
+	size = 40
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalCheckpoint object.
+func (m *MinimalCheckpoint) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 40 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Epoch'
+	m.Epoch = ssz.UnmarshalUint64(buf[0:8])
+
+	// Field (1) 'Root'
+	m.Root = append(m.Root[:0], buf[8:40]...)
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalCheckpoint object.
+func (m *MinimalCheckpoint) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalCheckpoint)
+	}
+	var err error
+	roots := make([][]byte, 0, 2)
+
+	// Field (0) 'Epoch'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Epoch)
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Root'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Root, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+	return ssz.Merkleize(roots, 2)
+}
+
+// MarshalSSZ ssz marshals the MinimalValidator object.
+func (m *MinimalValidator) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalValidator object and appends it to buf.
+func (m *MinimalValidator) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalValidator)
+	}
+	dst = buf
+
+	// Field (0) 'Pubkey'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Pubkey, 48); err != nil {
+		return nil, err
+	}
+
+	// Field (1) 'WithdrawalCredentials'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.WithdrawalCredentials, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'EffectiveBalance'
+	dst = ssz.MarshalUint64(dst, m.EffectiveBalance)
+
+	// Field (3) 'Slashed'
+	dst = ssz.MarshalBool(dst, m.Slashed)
+
+	// Field (4) 'ActivationEligibilityEpoch'
+	dst = ssz.MarshalUint64(dst, m.ActivationEligibilityEpoch)
+
+	// Field (5) 'ActivationEpoch'
+	dst = ssz.MarshalUint64(dst, m.ActivationEpoch)
+
+	// Field (6) 'ExitEpoch'
+	dst = ssz.MarshalUint64(dst, m.ExitEpoch)
+
+	// Field (7) 'WithdrawableEpoch'
+	dst = ssz.MarshalUint64(dst, m.WithdrawableEpoch)
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalValidator object.
+func (m *MinimalValidator) SizeSSZ() (size int) {
+	size = 121
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalValidator object.
+func (m *MinimalValidator) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 121 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Pubkey'
+	m.Pubkey = append(m.Pubkey[:0], buf[0:48]...)
+
+	// Field (1) 'WithdrawalCredentials'
+	m.WithdrawalCredentials = append(m.WithdrawalCredentials[:0], buf[48:80]...)
+
+	// Field (2) 'EffectiveBalance'
+	m.EffectiveBalance = ssz.UnmarshalUint64(buf[80:88])
+
+	// Field (3) 'Slashed'
+	if m.Slashed, err = ssz.UnmarshalBool(buf[88:89]); err != nil {
+		return err
+	}
+
+	// Field (4) 'ActivationEligibilityEpoch'
+	m.ActivationEligibilityEpoch = ssz.UnmarshalUint64(buf[89:97])
+
+	// Field (5) 'ActivationEpoch'
+	m.ActivationEpoch = ssz.UnmarshalUint64(buf[97:105])
+
+	// Field (6) 'ExitEpoch'
+	m.ExitEpoch = ssz.UnmarshalUint64(buf[105:113])
+
+	// Field (7) 'WithdrawableEpoch'
+	m.WithdrawableEpoch = ssz.UnmarshalUint64(buf[113:121])
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalValidator object.
+func (m *MinimalValidator) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalValidator)
+	}
+	var err error
+	roots := make([][]byte, 0, 8)
+
+	// Field (0) 'Pubkey'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Pubkey, 48); err != nil {
+			return [32]byte{}, err
+		}
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 2); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'WithdrawalCredentials'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.WithdrawalCredentials, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'EffectiveBalance'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.EffectiveBalance)
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'Slashed'
+	var root3 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalBool(buf0, m.Slashed)
+		if root3, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root3[:])
+
+	// Field (4) 'ActivationEligibilityEpoch'
+	var root4 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.ActivationEligibilityEpoch)
+		if root4, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root4[:])
+
+	// Field (5) 'ActivationEpoch'
+	var root5 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.ActivationEpoch)
+		if root5, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root5[:])
+
+	// Field (6) 'ExitEpoch'
+	var root6 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.ExitEpoch)
+		if root6, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root6[:])
+
+	// Field (7) 'WithdrawableEpoch'
+	var root7 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.WithdrawableEpoch)
+		if root7, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root7[:])
+	return ssz.Merkleize(roots, 8)
+}
+
+// MarshalSSZ ssz marshals the MinimalCrosslink object.
+func (m *MinimalCrosslink) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalCrosslink object and appends it to buf.
+func (m *MinimalCrosslink) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalCrosslink)
+	}
+	dst = buf
+
+	// Field (0) 'Shard'
+	dst = ssz.MarshalUint64(dst, m.Shard)
+
+	// Field (1) 'ParentRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.ParentRoot, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'StartEpoch'
+	dst = ssz.MarshalUint64(dst, m.StartEpoch)
+
+	// Field (3) 'EndEpoch'
+	dst = ssz.MarshalUint64(dst, m.EndEpoch)
+
+	// Field (4) 'DataRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.DataRoot, 32); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalCrosslink object.
+func (m *MinimalCrosslink) SizeSSZ() (size int) {
+	size = 88
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalCrosslink object.
+func (m *MinimalCrosslink) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 88 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Shard'
+	m.Shard = ssz.UnmarshalUint64(buf[0:8])
+
+	// Field (1) 'ParentRoot'
+	m.ParentRoot = append(m.ParentRoot[:0], buf[8:40]...)
+
+	// Field (2) 'StartEpoch'
+	m.StartEpoch = ssz.UnmarshalUint64(buf[40:48])
+
+	// Field (3) 'EndEpoch'
+	m.EndEpoch = ssz.UnmarshalUint64(buf[48:56])
+
+	// Field (4) 'DataRoot'
+	m.DataRoot = append(m.DataRoot[:0], buf[56:88]...)
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalCrosslink object.
+func (m *MinimalCrosslink) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalCrosslink)
+	}
+	var err error
+	roots := make([][]byte, 0, 5)
+
+	// Field (0) 'Shard'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Shard)
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'ParentRoot'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.ParentRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'StartEpoch'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.StartEpoch)
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'EndEpoch'
+	var root3 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.EndEpoch)
+		if root3, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root3[:])
+
+	// Field (4) 'DataRoot'
+	var root4 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.DataRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root4, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root4[:])
+	return ssz.Merkleize(roots, 5)
+}
+
+// MarshalSSZ ssz marshals the MinimalAttestationData object.
+func (m *MinimalAttestationData) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalAttestationData object and appends it to buf.
+func (m *MinimalAttestationData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalAttestationData)
+	}
+	dst = buf
+
+	// Field (0) 'BeaconBlockRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.BeaconBlockRoot, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (1) 'Source'
+	if dst, err = m.Source.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'Target'
+	if dst, err = m.Target.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (3) 'Crosslink'
+	if dst, err = m.Crosslink.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalAttestationData object.
+func (m *MinimalAttestationData) SizeSSZ() (size int) {
+	size = 200
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalAttestationData object.
+func (m *MinimalAttestationData) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 200 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'BeaconBlockRoot'
+	m.BeaconBlockRoot = append(m.BeaconBlockRoot[:0], buf[0:32]...)
+
+	// Field (1) 'Source'
+	if err = m.Source.UnmarshalSSZ(buf[32:72]); err != nil {
+		return err
+	}
+
+	// Field (2) 'Target'
+	if err = m.Target.UnmarshalSSZ(buf[72:112]); err != nil {
+		return err
+	}
+
+	// Field (3) 'Crosslink'
+	if err = m.Crosslink.UnmarshalSSZ(buf[112:200]); err != nil {
+		return err
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalAttestationData object.
+func (m *MinimalAttestationData) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalAttestationData)
+	}
+	var err error
+	roots := make([][]byte, 0, 4)
+
+	// Field (0) 'BeaconBlockRoot'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.BeaconBlockRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Source'
+	var root1 [32]byte
+	if root1, err = m.Source.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Target'
+	var root2 [32]byte
+	if root2, err = m.Target.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'Crosslink'
+	var root3 [32]byte
+	if root3, err = m.Crosslink.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root3[:])
+	return ssz.Merkleize(roots, 4)
+}
+
+// MarshalSSZ ssz marshals the MinimalAttestationAndCustodyBit object.
+func (m *MinimalAttestationAndCustodyBit) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalAttestationAndCustodyBit object and appends it to buf.
+func (m *MinimalAttestationAndCustodyBit) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalAttestationAndCustodyBit)
+	}
+	dst = buf
+
+	// Field (0) 'Data'
+	if dst, err = m.Data.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (1) 'CustodyBit'
+	dst = ssz.MarshalBool(dst, m.CustodyBit)
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalAttestationAndCustodyBit object.
+func (m *MinimalAttestationAndCustodyBit) SizeSSZ() (size int) {
+	size = 201
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalAttestationAndCustodyBit object.
+func (m *MinimalAttestationAndCustodyBit) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 201 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Data'
+	if err = m.Data.UnmarshalSSZ(buf[0:200]); err != nil {
+		return err
+	}
+
+	// Field (1) 'CustodyBit'
+	if m.CustodyBit, err = ssz.UnmarshalBool(buf[200:201]); err != nil {
+		return err
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalAttestationAndCustodyBit object.
+func (m *MinimalAttestationAndCustodyBit) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalAttestationAndCustodyBit)
+	}
+	var err error
+	roots := make([][]byte, 0, 2)
+
+	// Field (0) 'Data'
+	var root0 [32]byte
+	if root0, err = m.Data.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'CustodyBit'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalBool(buf0, m.CustodyBit)
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+	return ssz.Merkleize(roots, 2)
+}
+
+// MarshalSSZ ssz marshals the MinimalIndexedAttestation object.
+func (m *MinimalIndexedAttestation) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalIndexedAttestation object and appends it to buf.
+func (m *MinimalIndexedAttestation) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalIndexedAttestation)
+	}
+	dst = buf
+	offset := 304
+
+	// Field (0) 'CustodyBit0Indices'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.CustodyBit0Indices) * 8
+
+	// Field (1) 'CustodyBit1Indices'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.CustodyBit1Indices) * 8
+
+	// Field (2) 'Data'
+	if dst, err = m.Data.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (3) 'Signature'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Signature, 96); err != nil {
+		return nil, err
+	}
+
+	// Field (0) 'CustodyBit0Indices'
+	if uint64(len(m.CustodyBit0Indices)) > 4096 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.CustodyBit0Indices {
+		dst = ssz.MarshalUint64(dst, m.CustodyBit0Indices[i0])
+	}
+
+	// Field (1) 'CustodyBit1Indices'
+	if uint64(len(m.CustodyBit1Indices)) > 4096 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.CustodyBit1Indices {
+		dst = ssz.MarshalUint64(dst, m.CustodyBit1Indices[i0])
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalIndexedAttestation object.
+func (m *MinimalIndexedAttestation) SizeSSZ() (size int) {
+	if m == nil {
+		m = new(MinimalIndexedAttestation)
+	}
+	size = 304
+
+	// Field (0) 'CustodyBit0Indices'
+	size += len(m.CustodyBit0Indices) * 8
+
+	// Field (1) 'CustodyBit1Indices'
+	size += len(m.CustodyBit1Indices) * 8
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalIndexedAttestation object.
+func (m *MinimalIndexedAttestation) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 304 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'CustodyBit0Indices'
+	o0 := ssz.ReadOffset(buf[0:4])
+	if o0 != 304 {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (1) 'CustodyBit1Indices'
+	o1 := ssz.ReadOffset(buf[4:8])
+	if o1 < o0 || o1 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (2) 'Data'
+	if err = m.Data.UnmarshalSSZ(buf[8:208]); err != nil {
+		return err
+	}
+
+	// Field (3) 'Signature'
+	m.Signature = append(m.Signature[:0], buf[208:304]...)
+
+	// Field (0) 'CustodyBit0Indices'
+	{
+		buf := buf[o0:o1]
+		if uint64(len(buf))%8 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 8
+		if uint64(num0) > 4096 {
+			return ssz.ErrListTooBig
+		}
+		m.CustodyBit0Indices = make([]uint64, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			m.CustodyBit0Indices[i0] = ssz.UnmarshalUint64(buf[i0*8 : (i0+1)*8])
+		}
+	}
+
+	// Field (1) 'CustodyBit1Indices'
+	{
+		buf := buf[o1:]
+		if uint64(len(buf))%8 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 8
+		if uint64(num0) > 4096 {
+			return ssz.ErrListTooBig
+		}
+		m.CustodyBit1Indices = make([]uint64, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			m.CustodyBit1Indices[i0] = ssz.UnmarshalUint64(buf[i0*8 : (i0+1)*8])
+		}
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalIndexedAttestation object.
+func (m *MinimalIndexedAttestation) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalIndexedAttestation)
+	}
+	var err error
+	roots := make([][]byte, 0, 4)
+
+	// Field (0) 'CustodyBit0Indices'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		if uint64(len(m.CustodyBit0Indices)) > 4096 {
+			return [32]byte{}, ssz.ErrListTooBig
+		}
+		for i0 := range m.CustodyBit0Indices {
+			buf0 = ssz.MarshalUint64(buf0, m.CustodyBit0Indices[i0])
+		}
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1024); err != nil {
+			return [32]byte{}, err
+		}
+		root0 = ssz.MixInLength(root0, uint64(len(m.CustodyBit0Indices)))
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'CustodyBit1Indices'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if uint64(len(m.CustodyBit1Indices)) > 4096 {
+			return [32]byte{}, ssz.ErrListTooBig
+		}
+		for i0 := range m.CustodyBit1Indices {
+			buf0 = ssz.MarshalUint64(buf0, m.CustodyBit1Indices[i0])
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1024); err != nil {
+			return [32]byte{}, err
+		}
+		root1 = ssz.MixInLength(root1, uint64(len(m.CustodyBit1Indices)))
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Data'
+	var root2 [32]byte
+	if root2, err = m.Data.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'Signature'
+	var root3 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Signature, 96); err != nil {
+			return [32]byte{}, err
+		}
+		if root3, err = ssz.Merkleize(ssz.Pack(buf0), 3); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root3[:])
+	return ssz.Merkleize(roots, 4)
+}
+
+// MarshalSSZ ssz marshals the MinimalPendingAttestation object.
+func (m *MinimalPendingAttestation) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalPendingAttestation object and appends it to buf.
+func (m *MinimalPendingAttestation) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalPendingAttestation)
+	}
+	dst = buf
+	offset := 220
+
+	// Field (0) 'AggregationBits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.AggregationBits)
+
+	// Field (1) 'Data'
+	if dst, err = m.Data.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'InclusionDelay'
+	dst = ssz.MarshalUint64(dst, m.InclusionDelay)
+
+	// Field (3) 'ProposerIndex'
+	dst = ssz.MarshalUint64(dst, m.ProposerIndex)
+
+	// Field (0) 'AggregationBits'
+	if m.AggregationBits.Len() > 4096 {
+		return nil, ssz.ErrListTooBig
+	}
+	dst = append(dst, m.AggregationBits...)
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalPendingAttestation object.
+func (m *MinimalPendingAttestation) SizeSSZ() (size int) {
+	if m == nil {
+		m = new(MinimalPendingAttestation)
+	}
+	size = 220
+
+	// Field (0) 'AggregationBits'
+	size += len(m.AggregationBits)
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalPendingAttestation object.
+func (m *MinimalPendingAttestation) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 220 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'AggregationBits'
+	o0 := ssz.ReadOffset(buf[0:4])
+	if o0 != 220 {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (1) 'Data'
+	if err = m.Data.UnmarshalSSZ(buf[4:204]); err != nil {
+		return err
+	}
+
+	// Field (2) 'InclusionDelay'
+	m.InclusionDelay = ssz.UnmarshalUint64(buf[204:212])
+
+	// Field (3) 'ProposerIndex'
+	m.ProposerIndex = ssz.UnmarshalUint64(buf[212:220])
+
+	// Field (0) 'AggregationBits'
+	{
+		buf := buf[o0:]
+		if err = ssz.ValidateBitlist(buf, 4096); err != nil {
+			return err
+		}
+		m.AggregationBits = append(m.AggregationBits[:0], buf...)
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalPendingAttestation object.
+func (m *MinimalPendingAttestation) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalPendingAttestation)
+	}
+	var err error
+	roots := make([][]byte, 0, 4)
+
+	// Field (0) 'AggregationBits'
+	var root0 [32]byte
+	if root0, err = ssz.BitlistRoot(m.AggregationBits, 4096); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Data'
+	var root1 [32]byte
+	if root1, err = m.Data.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'InclusionDelay'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.InclusionDelay)
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'ProposerIndex'
+	var root3 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.ProposerIndex)
+		if root3, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root3[:])
+	return ssz.Merkleize(roots, 4)
+}
+
+// MarshalSSZ ssz marshals the MinimalEth1Data object.
+func (m *MinimalEth1Data) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalEth1Data object and appends it to buf.
+func (m *MinimalEth1Data) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalEth1Data)
+	}
+	dst = buf
+
+	// Field (0) 'DepositRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.DepositRoot, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (1) 'DepositCount'
+	dst = ssz.MarshalUint64(dst, m.DepositCount)
+
+	// Field (2) 'BlockHash'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.BlockHash, 32); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalEth1Data object.
+func (m *MinimalEth1Data) SizeSSZ() (size int) {
+	size = 72
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalEth1Data object.
+func (m *MinimalEth1Data) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 72 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'DepositRoot'
+	m.DepositRoot = append(m.DepositRoot[:0], buf[0:32]...)
+
+	// Field (1) 'DepositCount'
+	m.DepositCount = ssz.UnmarshalUint64(buf[32:40])
+
+	// Field (2) 'BlockHash'
+	m.BlockHash = append(m.BlockHash[:0], buf[40:72]...)
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalEth1Data object.
+func (m *MinimalEth1Data) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalEth1Data)
+	}
+	var err error
+	roots := make([][]byte, 0, 3)
+
+	// Field (0) 'DepositRoot'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.DepositRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'DepositCount'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.DepositCount)
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'BlockHash'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.BlockHash, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+	return ssz.Merkleize(roots, 3)
+}
+
+// MarshalSSZ ssz marshals the MinimalHistoricalBatch object.
+func (m *MinimalHistoricalBatch) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalHistoricalBatch object and appends it to buf.
+func (m *MinimalHistoricalBatch) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalHistoricalBatch)
+	}
+	dst = buf
+
+	// Field (0) 'BlockRoots'
+	if len(m.BlockRoots) != 64 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.BlockRoots {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.BlockRoots[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (1) 'StateRoots'
+	if len(m.StateRoots) != 64 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.StateRoots {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.StateRoots[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalHistoricalBatch object.
+func (m *MinimalHistoricalBatch) SizeSSZ() (size int) {
+	size = 4096
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalHistoricalBatch object.
+func (m *MinimalHistoricalBatch) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 4096 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'BlockRoots'
+	{
+		buf := buf[0:2048]
+		m.BlockRoots = make([][]byte, 64)
+		for i0 := 0; i0 < 64; i0++ {
+			m.BlockRoots[i0] = append(m.BlockRoots[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+
+	// Field (1) 'StateRoots'
+	{
+		buf := buf[2048:4096]
+		m.StateRoots = make([][]byte, 64)
+		for i0 := 0; i0 < 64; i0++ {
+			m.StateRoots[i0] = append(m.StateRoots[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalHistoricalBatch object.
+func (m *MinimalHistoricalBatch) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalHistoricalBatch)
+	}
+	var err error
+	roots := make([][]byte, 0, 2)
+
+	// Field (0) 'BlockRoots'
+	var root0 [32]byte
+	if len(m.BlockRoots) != 64 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.BlockRoots))
+		for i0 := range m.BlockRoots {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.BlockRoots[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root0, err = ssz.Merkleize(roots0, 64); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'StateRoots'
+	var root1 [32]byte
+	if len(m.StateRoots) != 64 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.StateRoots))
+		for i0 := range m.StateRoots {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.StateRoots[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root1, err = ssz.Merkleize(roots0, 64); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+	return ssz.Merkleize(roots, 2)
+}
+
+// MarshalSSZ ssz marshals the MinimalDepositData object.
+func (m *MinimalDepositData) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalDepositData object and appends it to buf.
+func (m *MinimalDepositData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalDepositData)
+	}
+	dst = buf
+
+	// Field (0) 'Pubkey'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Pubkey, 48); err != nil {
+		return nil, err
+	}
+
+	// Field (1) 'WithdrawalCredentials'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.WithdrawalCredentials, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'Amount'
+	dst = ssz.MarshalUint64(dst, m.Amount)
+
+	// Field (3) 'Signature'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Signature, 96); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalDepositData object.
+func (m *MinimalDepositData) SizeSSZ() (size int) {
+	size = 184
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalDepositData object.
+func (m *MinimalDepositData) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 184 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Pubkey'
+	m.Pubkey = append(m.Pubkey[:0], buf[0:48]...)
+
+	// Field (1) 'WithdrawalCredentials'
+	m.WithdrawalCredentials = append(m.WithdrawalCredentials[:0], buf[48:80]...)
+
+	// Field (2) 'Amount'
+	m.Amount = ssz.UnmarshalUint64(buf[80:88])
+
+	// Field (3) 'Signature'
+	m.Signature = append(m.Signature[:0], buf[88:184]...)
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalDepositData object.
+func (m *MinimalDepositData) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalDepositData)
+	}
+	var err error
+	roots := make([][]byte, 0, 4)
+
+	// Field (0) 'Pubkey'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Pubkey, 48); err != nil {
+			return [32]byte{}, err
+		}
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 2); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'WithdrawalCredentials'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.WithdrawalCredentials, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Amount'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Amount)
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'Signature'
+	var root3 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Signature, 96); err != nil {
+			return [32]byte{}, err
+		}
+		if root3, err = ssz.Merkleize(ssz.Pack(buf0), 3); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root3[:])
+	return ssz.Merkleize(roots, 4)
+}
+
+// MarshalSSZ ssz marshals the MinimalCompactCommittee object.
+func (m *MinimalCompactCommittee) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalCompactCommittee object and appends it to buf.
+func (m *MinimalCompactCommittee) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalCompactCommittee)
+	}
+	dst = buf
+	offset := 8
+
+	// Field (0) 'Pubkeys'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Pubkeys) * 48
+
+	// Field (1) 'CompactValidators'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.CompactValidators) * 8
+
+	// Field (0) 'Pubkeys'
+	if uint64(len(m.Pubkeys)) > 4096 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.Pubkeys {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.Pubkeys[i0], 48); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (1) 'CompactValidators'
+	if uint64(len(m.CompactValidators)) > 4096 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.CompactValidators {
+		dst = ssz.MarshalUint64(dst, m.CompactValidators[i0])
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalCompactCommittee object.
+func (m *MinimalCompactCommittee) SizeSSZ() (size int) {
+	if m == nil {
+		m = new(MinimalCompactCommittee)
+	}
+	size = 8
+
+	// Field (0) 'Pubkeys'
+	size += len(m.Pubkeys) * 48
+
+	// Field (1) 'CompactValidators'
+	size += len(m.CompactValidators) * 8
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalCompactCommittee object.
+func (m *MinimalCompactCommittee) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 8 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Pubkeys'
+	o0 := ssz.ReadOffset(buf[0:4])
+	if o0 != 8 {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (1) 'CompactValidators'
+	o1 := ssz.ReadOffset(buf[4:8])
+	if o1 < o0 || o1 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (0) 'Pubkeys'
+	{
+		buf := buf[o0:o1]
+		if uint64(len(buf))%48 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 48
+		if uint64(num0) > 4096 {
+			return ssz.ErrListTooBig
+		}
+		m.Pubkeys = make([][]byte, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			m.Pubkeys[i0] = append(m.Pubkeys[i0][:0], buf[i0*48:(i0+1)*48]...)
+		}
+	}
+
+	// Field (1) 'CompactValidators'
+	{
+		buf := buf[o1:]
+		if uint64(len(buf))%8 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 8
+		if uint64(num0) > 4096 {
+			return ssz.ErrListTooBig
+		}
+		m.CompactValidators = make([]uint64, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			m.CompactValidators[i0] = ssz.UnmarshalUint64(buf[i0*8 : (i0+1)*8])
+		}
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalCompactCommittee object.
+func (m *MinimalCompactCommittee) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalCompactCommittee)
+	}
+	var err error
+	roots := make([][]byte, 0, 2)
+
+	// Field (0) 'Pubkeys'
+	var root0 [32]byte
+	if uint64(len(m.Pubkeys)) > 4096 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.Pubkeys))
+		for i0 := range m.Pubkeys {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.Pubkeys[i0], 48); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 2); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root0, err = ssz.Merkleize(roots0, 4096); err != nil {
+			return [32]byte{}, err
+		}
+		root0 = ssz.MixInLength(root0, uint64(len(m.Pubkeys)))
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'CompactValidators'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if uint64(len(m.CompactValidators)) > 4096 {
+			return [32]byte{}, ssz.ErrListTooBig
+		}
+		for i0 := range m.CompactValidators {
+			buf0 = ssz.MarshalUint64(buf0, m.CompactValidators[i0])
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1024); err != nil {
+			return [32]byte{}, err
+		}
+		root1 = ssz.MixInLength(root1, uint64(len(m.CompactValidators)))
+	}
+	roots = append(roots, root1[:])
+	return ssz.Merkleize(roots, 2)
+}
+
+// MarshalSSZ ssz marshals the MinimalBlockHeader object.
+func (m *MinimalBlockHeader) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalBlockHeader object and appends it to buf.
+func (m *MinimalBlockHeader) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalBlockHeader)
+	}
+	dst = buf
+
+	// Field (0) 'Slot'
+	dst = ssz.MarshalUint64(dst, m.Slot)
+
+	// Field (1) 'ParentRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.ParentRoot, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'StateRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.StateRoot, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (3) 'BodyRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.BodyRoot, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (4) 'Signature'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Signature, 96); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalBlockHeader object.
+func (m *MinimalBlockHeader) SizeSSZ() (size int) {
+	size = 200
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalBlockHeader object.
+func (m *MinimalBlockHeader) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 200 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Slot'
+	m.Slot = ssz.UnmarshalUint64(buf[0:8])
+
+	// Field (1) 'ParentRoot'
+	m.ParentRoot = append(m.ParentRoot[:0], buf[8:40]...)
+
+	// Field (2) 'StateRoot'
+	m.StateRoot = append(m.StateRoot[:0], buf[40:72]...)
+
+	// Field (3) 'BodyRoot'
+	m.BodyRoot = append(m.BodyRoot[:0], buf[72:104]...)
+
+	// Field (4) 'Signature'
+	m.Signature = append(m.Signature[:0], buf[104:200]...)
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalBlockHeader object.
+func (m *MinimalBlockHeader) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalBlockHeader)
+	}
+	var err error
+	roots := make([][]byte, 0, 5)
+
+	// Field (0) 'Slot'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Slot)
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'ParentRoot'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.ParentRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'StateRoot'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.StateRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'BodyRoot'
+	var root3 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.BodyRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root3, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root3[:])
+
+	// Field (4) 'Signature'
+	var root4 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Signature, 96); err != nil {
+			return [32]byte{}, err
+		}
+		if root4, err = ssz.Merkleize(ssz.Pack(buf0), 3); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root4[:])
+	return ssz.Merkleize(roots, 5)
+}
+
+// MarshalSSZ ssz marshals the MinimalProposerSlashing object.
+func (m *MinimalProposerSlashing) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalProposerSlashing object and appends it to buf.
+func (m *MinimalProposerSlashing) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalProposerSlashing)
+	}
+	dst = buf
+
+	// Field (0) 'ProposerIndex'
+	dst = ssz.MarshalUint64(dst, m.ProposerIndex)
+
+	// Field (1) 'Header1'
+	if dst, err = m.Header1.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'Header2'
+	if dst, err = m.Header2.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalProposerSlashing object.
+func (m *MinimalProposerSlashing) SizeSSZ() (size int) {
+	size = 408
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalProposerSlashing object.
+func (m *MinimalProposerSlashing) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 408 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'ProposerIndex'
+	m.ProposerIndex = ssz.UnmarshalUint64(buf[0:8])
+
+	// Field (1) 'Header1'
+	if err = m.Header1.UnmarshalSSZ(buf[8:208]); err != nil {
+		return err
+	}
+
+	// Field (2) 'Header2'
+	if err = m.Header2.UnmarshalSSZ(buf[208:408]); err != nil {
+		return err
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalProposerSlashing object.
+func (m *MinimalProposerSlashing) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalProposerSlashing)
+	}
+	var err error
+	roots := make([][]byte, 0, 3)
+
+	// Field (0) 'ProposerIndex'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.ProposerIndex)
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Header1'
+	var root1 [32]byte
+	if root1, err = m.Header1.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Header2'
+	var root2 [32]byte
+	if root2, err = m.Header2.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root2[:])
+	return ssz.Merkleize(roots, 3)
+}
+
+// MarshalSSZ ssz marshals the MinimalAttesterSlashing object.
+func (m *MinimalAttesterSlashing) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalAttesterSlashing object and appends it to buf.
+func (m *MinimalAttesterSlashing) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalAttesterSlashing)
+	}
+	dst = buf
+	offset := 8
+
+	// Field (0) 'Attestation1'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += m.Attestation1.SizeSSZ()
+
+	// Field (1) 'Attestation2'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += m.Attestation2.SizeSSZ()
+
+	// Field (0) 'Attestation1'
+	if dst, err = m.Attestation1.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (1) 'Attestation2'
+	if dst, err = m.Attestation2.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalAttesterSlashing object.
+func (m *MinimalAttesterSlashing) SizeSSZ() (size int) {
+	if m == nil {
+		m = new(MinimalAttesterSlashing)
+	}
+	size = 8
+
+	// Field (0) 'Attestation1'
+	size += m.Attestation1.SizeSSZ()
+
+	// Field (1) 'Attestation2'
+	size += m.Attestation2.SizeSSZ()
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalAttesterSlashing object.
+func (m *MinimalAttesterSlashing) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 8 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Attestation1'
+	o0 := ssz.ReadOffset(buf[0:4])
+	if o0 != 8 {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (1) 'Attestation2'
+	o1 := ssz.ReadOffset(buf[4:8])
+	if o1 < o0 || o1 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (0) 'Attestation1'
+	{
+		buf := buf[o0:o1]
+		if err = m.Attestation1.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (1) 'Attestation2'
+	{
+		buf := buf[o1:]
+		if err = m.Attestation2.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalAttesterSlashing object.
+func (m *MinimalAttesterSlashing) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalAttesterSlashing)
+	}
+	var err error
+	roots := make([][]byte, 0, 2)
+
+	// Field (0) 'Attestation1'
+	var root0 [32]byte
+	if root0, err = m.Attestation1.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Attestation2'
+	var root1 [32]byte
+	if root1, err = m.Attestation2.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root1[:])
+	return ssz.Merkleize(roots, 2)
+}
+
+// MarshalSSZ ssz marshals the MinimalAttestation object.
+func (m *MinimalAttestation) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalAttestation object and appends it to buf.
+func (m *MinimalAttestation) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalAttestation)
+	}
+	dst = buf
+	offset := 304
+
+	// Field (0) 'AggregationBits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.AggregationBits)
+
+	// Field (1) 'Data'
+	if dst, err = m.Data.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'CustodyBits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.CustodyBits)
+
+	// Field (3) 'Signature'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Signature, 96); err != nil {
+		return nil, err
+	}
+
+	// Field (0) 'AggregationBits'
+	if m.AggregationBits.Len() > 4096 {
+		return nil, ssz.ErrListTooBig
+	}
+	dst = append(dst, m.AggregationBits...)
+
+	// Field (2) 'CustodyBits'
+	if m.CustodyBits.Len() > 4096 {
+		return nil, ssz.ErrListTooBig
+	}
+	dst = append(dst, m.CustodyBits...)
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalAttestation object.
+func (m *MinimalAttestation) SizeSSZ() (size int) {
+	if m == nil {
+		m = new(MinimalAttestation)
+	}
+	size = 304
+
+	// Field (0) 'AggregationBits'
+	size += len(m.AggregationBits)
+
+	// Field (2) 'CustodyBits'
+	size += len(m.CustodyBits)
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalAttestation object.
+func (m *MinimalAttestation) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 304 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'AggregationBits'
+	o0 := ssz.ReadOffset(buf[0:4])
+	if o0 != 304 {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (1) 'Data'
+	if err = m.Data.UnmarshalSSZ(buf[4:204]); err != nil {
+		return err
+	}
+
+	// Field (2) 'CustodyBits'
+	o2 := ssz.ReadOffset(buf[204:208])
+	if o2 < o0 || o2 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (3) 'Signature'
+	m.Signature = append(m.Signature[:0], buf[208:304]...)
+
+	// Field (0) 'AggregationBits'
+	{
+		buf := buf[o0:o2]
+		if err = ssz.ValidateBitlist(buf, 4096); err != nil {
+			return err
+		}
+		m.AggregationBits = append(m.AggregationBits[:0], buf...)
+	}
+
+	// Field (2) 'CustodyBits'
+	{
+		buf := buf[o2:]
+		if err = ssz.ValidateBitlist(buf, 4096); err != nil {
+			return err
+		}
+		m.CustodyBits = append(m.CustodyBits[:0], buf...)
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalAttestation object.
+func (m *MinimalAttestation) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalAttestation)
+	}
+	var err error
+	roots := make([][]byte, 0, 4)
+
+	// Field (0) 'AggregationBits'
+	var root0 [32]byte
+	if root0, err = ssz.BitlistRoot(m.AggregationBits, 4096); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Data'
+	var root1 [32]byte
+	if root1, err = m.Data.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'CustodyBits'
+	var root2 [32]byte
+	if root2, err = ssz.BitlistRoot(m.CustodyBits, 4096); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'Signature'
+	var root3 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Signature, 96); err != nil {
+			return [32]byte{}, err
+		}
+		if root3, err = ssz.Merkleize(ssz.Pack(buf0), 3); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root3[:])
+	return ssz.Merkleize(roots, 4)
+}
+
+// MarshalSSZ ssz marshals the MinimalDeposit object.
+func (m *MinimalDeposit) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalDeposit object and appends it to buf.
+func (m *MinimalDeposit) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalDeposit)
+	}
+	dst = buf
+
+	// Field (0) 'Proof'
+	if len(m.Proof) != 33 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.Proof {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.Proof[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (1) 'Data'
+	if dst, err = m.Data.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalDeposit object.
+func (m *MinimalDeposit) SizeSSZ() (size int) {
+	size = 1240
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalDeposit object.
+func (m *MinimalDeposit) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 1240 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Proof'
+	{
+		buf := buf[0:1056]
+		m.Proof = make([][]byte, 33)
+		for i0 := 0; i0 < 33; i0++ {
+			m.Proof[i0] = append(m.Proof[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+
+	// Field (1) 'Data'
+	if err = m.Data.UnmarshalSSZ(buf[1056:1240]); err != nil {
+		return err
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalDeposit object.
+func (m *MinimalDeposit) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalDeposit)
+	}
+	var err error
+	roots := make([][]byte, 0, 2)
+
+	// Field (0) 'Proof'
+	var root0 [32]byte
+	if len(m.Proof) != 33 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.Proof))
+		for i0 := range m.Proof {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.Proof[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root0, err = ssz.Merkleize(roots0, 33); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Data'
+	var root1 [32]byte
+	if root1, err = m.Data.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root1[:])
+	return ssz.Merkleize(roots, 2)
+}
+
+// MarshalSSZ ssz marshals the MinimalVoluntaryExit object.
+func (m *MinimalVoluntaryExit) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalVoluntaryExit object and appends it to buf.
+func (m *MinimalVoluntaryExit) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalVoluntaryExit)
+	}
+	dst = buf
+
+	// Field (0) 'Epoch'
+	dst = ssz.MarshalUint64(dst, m.Epoch)
+
+	// Field (1) 'ValidatorIndex'
+	dst = ssz.MarshalUint64(dst, m.ValidatorIndex)
+
+	// Field (2) 'Signature'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Signature, 96); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalVoluntaryExit object.
+func (m *MinimalVoluntaryExit) SizeSSZ() (size int) {
+	size = 112
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalVoluntaryExit object.
+func (m *MinimalVoluntaryExit) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 112 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Epoch'
+	m.Epoch = ssz.UnmarshalUint64(buf[0:8])
+
+	// Field (1) 'ValidatorIndex'
+	m.ValidatorIndex = ssz.UnmarshalUint64(buf[8:16])
+
+	// Field (2) 'Signature'
+	m.Signature = append(m.Signature[:0], buf[16:112]...)
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalVoluntaryExit object.
+func (m *MinimalVoluntaryExit) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalVoluntaryExit)
+	}
+	var err error
+	roots := make([][]byte, 0, 3)
+
+	// Field (0) 'Epoch'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Epoch)
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'ValidatorIndex'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.ValidatorIndex)
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Signature'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Signature, 96); err != nil {
+			return [32]byte{}, err
+		}
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 3); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+	return ssz.Merkleize(roots, 3)
+}
+
+// MarshalSSZ ssz marshals the MinimalTransfer object.
+func (m *MinimalTransfer) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalTransfer object and appends it to buf.
+func (m *MinimalTransfer) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalTransfer)
+	}
+	dst = buf
+
+	// Field (0) 'Sender'
+	dst = ssz.MarshalUint64(dst, m.Sender)
+
+	// Field (1) 'Recipient'
+	dst = ssz.MarshalUint64(dst, m.Recipient)
+
+	// Field (2) 'Amount'
+	dst = ssz.MarshalUint64(dst, m.Amount)
+
+	// Field (3) 'Fee'
+	dst = ssz.MarshalUint64(dst, m.Fee)
+
+	// Field (4) 'Slot'
+	dst = ssz.MarshalUint64(dst, m.Slot)
+
+	// Field (5) 'Pubkey'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Pubkey, 48); err != nil {
+		return nil, err
+	}
+
+	// Field (6) 'Signature'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Signature, 96); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalTransfer object.
+func (m *MinimalTransfer) SizeSSZ() (size int) {
+	size = 184
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalTransfer object.
+func (m *MinimalTransfer) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 184 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Sender'
+	m.Sender = ssz.UnmarshalUint64(buf[0:8])
+
+	// Field (1) 'Recipient'
+	m.Recipient = ssz.UnmarshalUint64(buf[8:16])
+
+	// Field (2) 'Amount'
+	m.Amount = ssz.UnmarshalUint64(buf[16:24])
+
+	// Field (3) 'Fee'
+	m.Fee = ssz.UnmarshalUint64(buf[24:32])
+
+	// Field (4) 'Slot'
+	m.Slot = ssz.UnmarshalUint64(buf[32:40])
+
+	// Field (5) 'Pubkey'
+	m.Pubkey = append(m.Pubkey[:0], buf[40:88]...)
+
+	// Field (6) 'Signature'
+	m.Signature = append(m.Signature[:0], buf[88:184]...)
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalTransfer object.
+func (m *MinimalTransfer) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalTransfer)
+	}
+	var err error
+	roots := make([][]byte, 0, 7)
+
+	// Field (0) 'Sender'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Sender)
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Recipient'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Recipient)
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Amount'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Amount)
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'Fee'
+	var root3 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Fee)
+		if root3, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root3[:])
+
+	// Field (4) 'Slot'
+	var root4 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Slot)
+		if root4, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root4[:])
+
+	// Field (5) 'Pubkey'
+	var root5 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Pubkey, 48); err != nil {
+			return [32]byte{}, err
+		}
+		if root5, err = ssz.Merkleize(ssz.Pack(buf0), 2); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root5[:])
+
+	// Field (6) 'Signature'
+	var root6 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Signature, 96); err != nil {
+			return [32]byte{}, err
+		}
+		if root6, err = ssz.Merkleize(ssz.Pack(buf0), 3); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root6[:])
+	return ssz.Merkleize(roots, 7)
+}
+
+// MarshalSSZ ssz marshals the MinimalBlockBody object.
+func (m *MinimalBlockBody) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalBlockBody object and appends it to buf.
+func (m *MinimalBlockBody) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalBlockBody)
+	}
+	dst = buf
+	offset := 224
+
+	// Field (0) 'RandaoReveal'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.RandaoReveal, 96); err != nil {
+		return nil, err
+	}
+
+	// Field (1) 'Eth1Data'
+	if dst, err = m.Eth1Data.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'Graffiti'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Graffiti, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (3) 'ProposerSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.ProposerSlashings) * 408
+
+	// Field (4) 'AttesterSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	for i0 := range m.AttesterSlashings {
+		offset += 4
+		offset += m.AttesterSlashings[i0].SizeSSZ()
+	}
+
+	// Field (5) 'Attestations'
+	dst = ssz.WriteOffset(dst, offset)
+	for i0 := range m.Attestations {
+		offset += 4
+		offset += m.Attestations[i0].SizeSSZ()
+	}
+
+	// Field (6) 'Deposits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Deposits) * 1240
+
+	// Field (7) 'VoluntaryExits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.VoluntaryExits) * 112
+
+	// Field (8) 'Transfers'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Transfers) * 184
+
+	// Field (3) 'ProposerSlashings'
+	if uint64(len(m.ProposerSlashings)) > 16 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.ProposerSlashings {
+		if dst, err = m.ProposerSlashings[i0].MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (4) 'AttesterSlashings'
+	if uint64(len(m.AttesterSlashings)) > 1 {
+		return nil, ssz.ErrListTooBig
+	}
+	{
+		offset0 := 4 * len(m.AttesterSlashings)
+		for i0 := range m.AttesterSlashings {
+			dst = ssz.WriteOffset(dst, offset0)
+			offset0 += m.AttesterSlashings[i0].SizeSSZ()
+		}
+		for i0 := range m.AttesterSlashings {
+			if dst, err = m.AttesterSlashings[i0].MarshalSSZTo(dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Field (5) 'Attestations'
+	if uint64(len(m.Attestations)) > 128 {
+		return nil, ssz.ErrListTooBig
+	}
+	{
+		offset0 := 4 * len(m.Attestations)
+		for i0 := range m.Attestations {
+			dst = ssz.WriteOffset(dst, offset0)
+			offset0 += m.Attestations[i0].SizeSSZ()
+		}
+		for i0 := range m.Attestations {
+			if dst, err = m.Attestations[i0].MarshalSSZTo(dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Field (6) 'Deposits'
+	if uint64(len(m.Deposits)) > 16 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.Deposits {
+		if dst, err = m.Deposits[i0].MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (7) 'VoluntaryExits'
+	if uint64(len(m.VoluntaryExits)) > 16 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.VoluntaryExits {
+		if dst, err = m.VoluntaryExits[i0].MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (8) 'Transfers'
+	if uint64(len(m.Transfers)) > 0 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.Transfers {
+		if dst, err = m.Transfers[i0].MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalBlockBody object.
+func (m *MinimalBlockBody) SizeSSZ() (size int) {
+	if m == nil {
+		m = new(MinimalBlockBody)
+	}
+	size = 224
+
+	// Field (3) 'ProposerSlashings'
+	size += len(m.ProposerSlashings) * 408
+
+	// Field (4) 'AttesterSlashings'
+	for i0 := range m.AttesterSlashings {
+		size += 4
+		size += m.AttesterSlashings[i0].SizeSSZ()
+	}
+
+	// Field (5) 'Attestations'
+	for i0 := range m.Attestations {
+		size += 4
+		size += m.Attestations[i0].SizeSSZ()
+	}
+
+	// Field (6) 'Deposits'
+	size += len(m.Deposits) * 1240
+
+	// Field (7) 'VoluntaryExits'
+	size += len(m.VoluntaryExits) * 112
+
+	// Field (8) 'Transfers'
+	size += len(m.Transfers) * 184
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalBlockBody object.
+func (m *MinimalBlockBody) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 224 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'RandaoReveal'
+	m.RandaoReveal = append(m.RandaoReveal[:0], buf[0:96]...)
+
+	// Field (1) 'Eth1Data'
+	if err = m.Eth1Data.UnmarshalSSZ(buf[96:168]); err != nil {
+		return err
+	}
+
+	// Field (2) 'Graffiti'
+	m.Graffiti = append(m.Graffiti[:0], buf[168:200]...)
+
+	// Field (3) 'ProposerSlashings'
+	o3 := ssz.ReadOffset(buf[200:204])
+	if o3 != 224 {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (4) 'AttesterSlashings'
+	o4 := ssz.ReadOffset(buf[204:208])
+	if o4 < o3 || o4 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (5) 'Attestations'
+	o5 := ssz.ReadOffset(buf[208:212])
+	if o5 < o4 || o5 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (6) 'Deposits'
+	o6 := ssz.ReadOffset(buf[212:216])
+	if o6 < o5 || o6 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (7) 'VoluntaryExits'
+	o7 := ssz.ReadOffset(buf[216:220])
+	if o7 < o6 || o7 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (8) 'Transfers'
+	o8 := ssz.ReadOffset(buf[220:224])
+	if o8 < o7 || o8 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (3) 'ProposerSlashings'
+	{
+		buf := buf[o3:o4]
+		if uint64(len(buf))%408 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 408
+		if uint64(num0) > 16 {
+			return ssz.ErrListTooBig
+		}
+		m.ProposerSlashings = make([]MinimalProposerSlashing, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			if err = m.ProposerSlashings[i0].UnmarshalSSZ(buf[i0*408 : (i0+1)*408]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (4) 'AttesterSlashings'
+	{
+		buf := buf[o4:o5]
+		items0, err := ssz.SplitOffsets(buf, 1)
+		if err != nil {
+			return err
+		}
+		m.AttesterSlashings = make([]MinimalAttesterSlashing, len(items0))
+		for i0 := range items0 {
+			if err = m.AttesterSlashings[i0].UnmarshalSSZ(items0[i0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (5) 'Attestations'
+	{
+		buf := buf[o5:o6]
+		items0, err := ssz.SplitOffsets(buf, 128)
+		if err != nil {
+			return err
+		}
+		m.Attestations = make([]MinimalAttestation, len(items0))
+		for i0 := range items0 {
+			if err = m.Attestations[i0].UnmarshalSSZ(items0[i0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (6) 'Deposits'
+	{
+		buf := buf[o6:o7]
+		if uint64(len(buf))%1240 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 1240
+		if uint64(num0) > 16 {
+			return ssz.ErrListTooBig
+		}
+		m.Deposits = make([]MinimalDeposit, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			if err = m.Deposits[i0].UnmarshalSSZ(buf[i0*1240 : (i0+1)*1240]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (7) 'VoluntaryExits'
+	{
+		buf := buf[o7:o8]
+		if uint64(len(buf))%112 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 112
+		if uint64(num0) > 16 {
+			return ssz.ErrListTooBig
+		}
+		m.VoluntaryExits = make([]MinimalVoluntaryExit, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			if err = m.VoluntaryExits[i0].UnmarshalSSZ(buf[i0*112 : (i0+1)*112]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (8) 'Transfers'
+	{
+		buf := buf[o8:]
+		if uint64(len(buf))%184 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 184
+		if uint64(num0) > 0 {
+			return ssz.ErrListTooBig
+		}
+		m.Transfers = make([]MinimalTransfer, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			if err = m.Transfers[i0].UnmarshalSSZ(buf[i0*184 : (i0+1)*184]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalBlockBody object.
+func (m *MinimalBlockBody) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalBlockBody)
+	}
+	var err error
+	roots := make([][]byte, 0, 9)
+
+	// Field (0) 'RandaoReveal'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.RandaoReveal, 96); err != nil {
+			return [32]byte{}, err
+		}
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 3); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Eth1Data'
+	var root1 [32]byte
+	if root1, err = m.Eth1Data.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Graffiti'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Graffiti, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'ProposerSlashings'
+	var root3 [32]byte
+	if uint64(len(m.ProposerSlashings)) > 16 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.ProposerSlashings))
+		for i0 := range m.ProposerSlashings {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.ProposerSlashings[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root3, err = ssz.Merkleize(roots0, 16); err != nil {
+			return [32]byte{}, err
+		}
+		root3 = ssz.MixInLength(root3, uint64(len(m.ProposerSlashings)))
+	}
+	roots = append(roots, root3[:])
+
+	// Field (4) 'AttesterSlashings'
+	var root4 [32]byte
+	if uint64(len(m.AttesterSlashings)) > 1 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.AttesterSlashings))
+		for i0 := range m.AttesterSlashings {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.AttesterSlashings[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root4, err = ssz.Merkleize(roots0, 1); err != nil {
+			return [32]byte{}, err
+		}
+		root4 = ssz.MixInLength(root4, uint64(len(m.AttesterSlashings)))
+	}
+	roots = append(roots, root4[:])
+
+	// Field (5) 'Attestations'
+	var root5 [32]byte
+	if uint64(len(m.Attestations)) > 128 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.Attestations))
+		for i0 := range m.Attestations {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.Attestations[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root5, err = ssz.Merkleize(roots0, 128); err != nil {
+			return [32]byte{}, err
+		}
+		root5 = ssz.MixInLength(root5, uint64(len(m.Attestations)))
+	}
+	roots = append(roots, root5[:])
+
+	// Field (6) 'Deposits'
+	var root6 [32]byte
+	if uint64(len(m.Deposits)) > 16 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.Deposits))
+		for i0 := range m.Deposits {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.Deposits[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root6, err = ssz.Merkleize(roots0, 16); err != nil {
+			return [32]byte{}, err
+		}
+		root6 = ssz.MixInLength(root6, uint64(len(m.Deposits)))
+	}
+	roots = append(roots, root6[:])
+
+	// Field (7) 'VoluntaryExits'
+	var root7 [32]byte
+	if uint64(len(m.VoluntaryExits)) > 16 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.VoluntaryExits))
+		for i0 := range m.VoluntaryExits {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.VoluntaryExits[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root7, err = ssz.Merkleize(roots0, 16); err != nil {
+			return [32]byte{}, err
+		}
+		root7 = ssz.MixInLength(root7, uint64(len(m.VoluntaryExits)))
+	}
+	roots = append(roots, root7[:])
+
+	// Field (8) 'Transfers'
+	var root8 [32]byte
+	if uint64(len(m.Transfers)) > 0 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.Transfers))
+		for i0 := range m.Transfers {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.Transfers[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root8, err = ssz.Merkleize(roots0, 0); err != nil {
+			return [32]byte{}, err
+		}
+		root8 = ssz.MixInLength(root8, uint64(len(m.Transfers)))
+	}
+	roots = append(roots, root8[:])
+	return ssz.Merkleize(roots, 9)
+}
+
+// MarshalSSZ ssz marshals the MinimalBlock object.
+func (m *MinimalBlock) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalBlock object and appends it to buf.
+func (m *MinimalBlock) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalBlock)
+	}
+	dst = buf
+	offset := 172
+
+	// Field (0) 'Slot'
+	dst = ssz.MarshalUint64(dst, m.Slot)
+
+	// Field (1) 'ParentRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.ParentRoot, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (2) 'StateRoot'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.StateRoot, 32); err != nil {
+		return nil, err
+	}
+
+	// Field (3) 'Body'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += m.Body.SizeSSZ()
+
+	// Field (4) 'Signature'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.Signature, 96); err != nil {
+		return nil, err
+	}
+
+	// Field (3) 'Body'
+	if dst, err = m.Body.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalBlock object.
+func (m *MinimalBlock) SizeSSZ() (size int) {
+	if m == nil {
+		m = new(MinimalBlock)
+	}
+	size = 172
+
+	// Field (3) 'Body'
+	size += m.Body.SizeSSZ()
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalBlock object.
+func (m *MinimalBlock) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 172 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'Slot'
+	m.Slot = ssz.UnmarshalUint64(buf[0:8])
+
+	// Field (1) 'ParentRoot'
+	m.ParentRoot = append(m.ParentRoot[:0], buf[8:40]...)
+
+	// Field (2) 'StateRoot'
+	m.StateRoot = append(m.StateRoot[:0], buf[40:72]...)
+
+	// Field (3) 'Body'
+	o3 := ssz.ReadOffset(buf[72:76])
+	if o3 != 172 {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (4) 'Signature'
+	m.Signature = append(m.Signature[:0], buf[76:172]...)
+
+	// Field (3) 'Body'
+	{
+		buf := buf[o3:]
+		if err = m.Body.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalBlock object.
+func (m *MinimalBlock) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalBlock)
+	}
+	var err error
+	roots := make([][]byte, 0, 5)
+
+	// Field (0) 'Slot'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Slot)
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'ParentRoot'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.ParentRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'StateRoot'
+	var root2 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.StateRoot, 32); err != nil {
+			return [32]byte{}, err
+		}
+		if root2, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'Body'
+	var root3 [32]byte
+	if root3, err = m.Body.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root3[:])
+
+	// Field (4) 'Signature'
+	var root4 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.Signature, 96); err != nil {
+			return [32]byte{}, err
+		}
+		if root4, err = ssz.Merkleize(ssz.Pack(buf0), 3); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root4[:])
+	return ssz.Merkleize(roots, 5)
+}
+
+// MarshalSSZ ssz marshals the MinimalBeaconState object.
+func (m *MinimalBeaconState) MarshalSSZ() ([]byte, error) {
+	return m.MarshalSSZTo(make([]byte, 0, m.SizeSSZ()))
+}
+
+// MarshalSSZTo ssz marshals the MinimalBeaconState object and appends it to buf.
+func (m *MinimalBeaconState) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	if m == nil {
+		m = new(MinimalBeaconState)
+	}
+	dst = buf
+	offset := 12625
+
+	// Field (0) 'GenesisTime'
+	dst = ssz.MarshalUint64(dst, m.GenesisTime)
+
+	// Field (1) 'Slot'
+	dst = ssz.MarshalUint64(dst, m.Slot)
+
+	// Field (2) 'Fork'
+	if dst, err = m.Fork.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (3) 'LatestBlockHeader'
+	if dst, err = m.LatestBlockHeader.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (4) 'BlockRoots'
+	if len(m.BlockRoots) != 64 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.BlockRoots {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.BlockRoots[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (5) 'StateRoots'
+	if len(m.StateRoots) != 64 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.StateRoots {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.StateRoots[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (6) 'HistoricalRoots'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.HistoricalRoots) * 32
+
+	// Field (7) 'Eth1Data'
+	if dst, err = m.Eth1Data.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (8) 'Eth1DataVotes'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Eth1DataVotes) * 72
+
+	// Field (9) 'Eth1DepositIndex'
+	dst = ssz.MarshalUint64(dst, m.Eth1DepositIndex)
+
+	// Field (10) 'Validators'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Validators) * 121
+
+	// Field (11) 'Balances'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Balances) * 8
+
+	// Field (12) 'StartShard'
+	dst = ssz.MarshalUint64(dst, m.StartShard)
+
+	// Field (13) 'RandaoMixes'
+	if len(m.RandaoMixes) != 64 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.RandaoMixes {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.RandaoMixes[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (14) 'ActiveIndexRoots'
+	if len(m.ActiveIndexRoots) != 64 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.ActiveIndexRoots {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.ActiveIndexRoots[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (15) 'CompactCommitteesRoots'
+	if len(m.CompactCommitteesRoots) != 64 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.CompactCommitteesRoots {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.CompactCommitteesRoots[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (16) 'Slashings'
+	if len(m.Slashings) != 64 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.Slashings {
+		dst = ssz.MarshalUint64(dst, m.Slashings[i0])
+	}
+
+	// Field (17) 'PreviousEpochAttestations'
+	dst = ssz.WriteOffset(dst, offset)
+	for i0 := range m.PreviousEpochAttestations {
+		offset += 4
+		offset += m.PreviousEpochAttestations[i0].SizeSSZ()
+	}
+
+	// Field (18) 'CurrentEpochAttestations'
+	dst = ssz.WriteOffset(dst, offset)
+	for i0 := range m.CurrentEpochAttestations {
+		offset += 4
+		offset += m.CurrentEpochAttestations[i0].SizeSSZ()
+	}
+
+	// Field (19) 'PreviousCrosslinks'
+	if len(m.PreviousCrosslinks) != 8 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.PreviousCrosslinks {
+		if dst, err = m.PreviousCrosslinks[i0].MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (20) 'CurrentCrosslinks'
+	if len(m.CurrentCrosslinks) != 8 {
+		return nil, ssz.ErrVectorLength
+	}
+	for i0 := range m.CurrentCrosslinks {
+		if dst, err = m.CurrentCrosslinks[i0].MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (21) 'JustificationBits'
+	if dst, err = ssz.MarshalFixedBytes(dst, m.JustificationBits, 1); err != nil {
+		return nil, err
+	}
+
+	// Field (22) 'PreviousJustifiedCheckpoint'
+	if dst, err = m.PreviousJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (23) 'CurrentJustifiedCheckpoint'
+	if dst, err = m.CurrentJustifiedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (24) 'FinalizedCheckpoint'
+	if dst, err = m.FinalizedCheckpoint.MarshalSSZTo(dst); err != nil {
+		return nil, err
+	}
+
+	// Field (6) 'HistoricalRoots'
+	if uint64(len(m.HistoricalRoots)) > 16777216 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.HistoricalRoots {
+		if dst, err = ssz.MarshalFixedBytes(dst, m.HistoricalRoots[i0], 32); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (8) 'Eth1DataVotes'
+	if uint64(len(m.Eth1DataVotes)) > 16 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.Eth1DataVotes {
+		if dst, err = m.Eth1DataVotes[i0].MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (10) 'Validators'
+	if uint64(len(m.Validators)) > 1099511627776 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.Validators {
+		if dst, err = m.Validators[i0].MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// Field (11) 'Balances'
+	if uint64(len(m.Balances)) > 1099511627776 {
+		return nil, ssz.ErrListTooBig
+	}
+	for i0 := range m.Balances {
+		dst = ssz.MarshalUint64(dst, m.Balances[i0])
+	}
+
+	// Field (17) 'PreviousEpochAttestations'
+	if uint64(len(m.PreviousEpochAttestations)) > 1024 {
+		return nil, ssz.ErrListTooBig
+	}
+	{
+		offset0 := 4 * len(m.PreviousEpochAttestations)
+		for i0 := range m.PreviousEpochAttestations {
+			dst = ssz.WriteOffset(dst, offset0)
+			offset0 += m.PreviousEpochAttestations[i0].SizeSSZ()
+		}
+		for i0 := range m.PreviousEpochAttestations {
+			if dst, err = m.PreviousEpochAttestations[i0].MarshalSSZTo(dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Field (18) 'CurrentEpochAttestations'
+	if uint64(len(m.CurrentEpochAttestations)) > 1024 {
+		return nil, ssz.ErrListTooBig
+	}
+	{
+		offset0 := 4 * len(m.CurrentEpochAttestations)
+		for i0 := range m.CurrentEpochAttestations {
+			dst = ssz.WriteOffset(dst, offset0)
+			offset0 += m.CurrentEpochAttestations[i0].SizeSSZ()
+		}
+		for i0 := range m.CurrentEpochAttestations {
+			if dst, err = m.CurrentEpochAttestations[i0].MarshalSSZTo(dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dst, nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the MinimalBeaconState object.
+func (m *MinimalBeaconState) SizeSSZ() (size int) {
+	if m == nil {
+		m = new(MinimalBeaconState)
+	}
+	size = 12625
+
+	// Field (6) 'HistoricalRoots'
+	size += len(m.HistoricalRoots) * 32
+
+	// Field (8) 'Eth1DataVotes'
+	size += len(m.Eth1DataVotes) * 72
+
+	// Field (10) 'Validators'
+	size += len(m.Validators) * 121
+
+	// Field (11) 'Balances'
+	size += len(m.Balances) * 8
+
+	// Field (17) 'PreviousEpochAttestations'
+	for i0 := range m.PreviousEpochAttestations {
+		size += 4
+		size += m.PreviousEpochAttestations[i0].SizeSSZ()
+	}
+
+	// Field (18) 'CurrentEpochAttestations'
+	for i0 := range m.CurrentEpochAttestations {
+		size += 4
+		size += m.CurrentEpochAttestations[i0].SizeSSZ()
+	}
+	return size
+}
+
+// UnmarshalSSZ ssz unmarshals the MinimalBeaconState object.
+func (m *MinimalBeaconState) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 12625 {
+		return ssz.ErrIncorrectSize
+	}
+
+	// Field (0) 'GenesisTime'
+	m.GenesisTime = ssz.UnmarshalUint64(buf[0:8])
+
+	// Field (1) 'Slot'
+	m.Slot = ssz.UnmarshalUint64(buf[8:16])
+
+	// Field (2) 'Fork'
+	if err = m.Fork.UnmarshalSSZ(buf[16:32]); err != nil {
+		return err
+	}
+
+	// Field (3) 'LatestBlockHeader'
+	if err = m.LatestBlockHeader.UnmarshalSSZ(buf[32:232]); err != nil {
+		return err
+	}
+
+	// Field (4) 'BlockRoots'
+	{
+		buf := buf[232:2280]
+		m.BlockRoots = make([][]byte, 64)
+		for i0 := 0; i0 < 64; i0++ {
+			m.BlockRoots[i0] = append(m.BlockRoots[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+
+	// Field (5) 'StateRoots'
+	{
+		buf := buf[2280:4328]
+		m.StateRoots = make([][]byte, 64)
+		for i0 := 0; i0 < 64; i0++ {
+			m.StateRoots[i0] = append(m.StateRoots[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+
+	// Field (6) 'HistoricalRoots'
+	o6 := ssz.ReadOffset(buf[4328:4332])
+	if o6 != 12625 {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (7) 'Eth1Data'
+	if err = m.Eth1Data.UnmarshalSSZ(buf[4332:4404]); err != nil {
+		return err
+	}
+
+	// Field (8) 'Eth1DataVotes'
+	o8 := ssz.ReadOffset(buf[4404:4408])
+	if o8 < o6 || o8 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (9) 'Eth1DepositIndex'
+	m.Eth1DepositIndex = ssz.UnmarshalUint64(buf[4408:4416])
+
+	// Field (10) 'Validators'
+	o10 := ssz.ReadOffset(buf[4416:4420])
+	if o10 < o8 || o10 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (11) 'Balances'
+	o11 := ssz.ReadOffset(buf[4420:4424])
+	if o11 < o10 || o11 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (12) 'StartShard'
+	m.StartShard = ssz.UnmarshalUint64(buf[4424:4432])
+
+	// Field (13) 'RandaoMixes'
+	{
+		buf := buf[4432:6480]
+		m.RandaoMixes = make([][]byte, 64)
+		for i0 := 0; i0 < 64; i0++ {
+			m.RandaoMixes[i0] = append(m.RandaoMixes[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+
+	// Field (14) 'ActiveIndexRoots'
+	{
+		buf := buf[6480:8528]
+		m.ActiveIndexRoots = make([][]byte, 64)
+		for i0 := 0; i0 < 64; i0++ {
+			m.ActiveIndexRoots[i0] = append(m.ActiveIndexRoots[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+
+	// Field (15) 'CompactCommitteesRoots'
+	{
+		buf := buf[8528:10576]
+		m.CompactCommitteesRoots = make([][]byte, 64)
+		for i0 := 0; i0 < 64; i0++ {
+			m.CompactCommitteesRoots[i0] = append(m.CompactCommitteesRoots[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+
+	// Field (16) 'Slashings'
+	{
+		buf := buf[10576:11088]
+		m.Slashings = make([]uint64, 64)
+		for i0 := 0; i0 < 64; i0++ {
+			m.Slashings[i0] = ssz.UnmarshalUint64(buf[i0*8 : (i0+1)*8])
+		}
+	}
+
+	// Field (17) 'PreviousEpochAttestations'
+	o17 := ssz.ReadOffset(buf[11088:11092])
+	if o17 < o11 || o17 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (18) 'CurrentEpochAttestations'
+	o18 := ssz.ReadOffset(buf[11092:11096])
+	if o18 < o17 || o18 > size {
+		return ssz.ErrInvalidOffset
+	}
+
+	// Field (19) 'PreviousCrosslinks'
+	{
+		buf := buf[11096:11800]
+		m.PreviousCrosslinks = make([]MinimalCrosslink, 8)
+		for i0 := 0; i0 < 8; i0++ {
+			if err = m.PreviousCrosslinks[i0].UnmarshalSSZ(buf[i0*88 : (i0+1)*88]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (20) 'CurrentCrosslinks'
+	{
+		buf := buf[11800:12504]
+		m.CurrentCrosslinks = make([]MinimalCrosslink, 8)
+		for i0 := 0; i0 < 8; i0++ {
+			if err = m.CurrentCrosslinks[i0].UnmarshalSSZ(buf[i0*88 : (i0+1)*88]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (21) 'JustificationBits'
+	m.JustificationBits = append(m.JustificationBits[:0], buf[12504:12505]...)
+
+	// Field (22) 'PreviousJustifiedCheckpoint'
+	if err = m.PreviousJustifiedCheckpoint.UnmarshalSSZ(buf[12505:12545]); err != nil {
+		return err
+	}
+
+	// Field (23) 'CurrentJustifiedCheckpoint'
+	if err = m.CurrentJustifiedCheckpoint.UnmarshalSSZ(buf[12545:12585]); err != nil {
+		return err
+	}
+
+	// Field (24) 'FinalizedCheckpoint'
+	if err = m.FinalizedCheckpoint.UnmarshalSSZ(buf[12585:12625]); err != nil {
+		return err
+	}
+
+	// Field (6) 'HistoricalRoots'
+	{
+		buf := buf[o6:o8]
+		if uint64(len(buf))%32 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 32
+		if uint64(num0) > 16777216 {
+			return ssz.ErrListTooBig
+		}
+		m.HistoricalRoots = make([][]byte, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			m.HistoricalRoots[i0] = append(m.HistoricalRoots[i0][:0], buf[i0*32:(i0+1)*32]...)
+		}
+	}
+
+	// Field (8) 'Eth1DataVotes'
+	{
+		buf := buf[o8:o10]
+		if uint64(len(buf))%72 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 72
+		if uint64(num0) > 16 {
+			return ssz.ErrListTooBig
+		}
+		m.Eth1DataVotes = make([]MinimalEth1Data, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			if err = m.Eth1DataVotes[i0].UnmarshalSSZ(buf[i0*72 : (i0+1)*72]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (10) 'Validators'
+	{
+		buf := buf[o10:o11]
+		if uint64(len(buf))%121 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 121
+		if uint64(num0) > 1099511627776 {
+			return ssz.ErrListTooBig
+		}
+		m.Validators = make([]MinimalValidator, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			if err = m.Validators[i0].UnmarshalSSZ(buf[i0*121 : (i0+1)*121]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (11) 'Balances'
+	{
+		buf := buf[o11:o17]
+		if uint64(len(buf))%8 != 0 {
+			return ssz.ErrIncorrectSize
+		}
+		num0 := len(buf) / 8
+		if uint64(num0) > 1099511627776 {
+			return ssz.ErrListTooBig
+		}
+		m.Balances = make([]uint64, num0)
+		for i0 := 0; i0 < num0; i0++ {
+			m.Balances[i0] = ssz.UnmarshalUint64(buf[i0*8 : (i0+1)*8])
+		}
+	}
+
+	// Field (17) 'PreviousEpochAttestations'
+	{
+		buf := buf[o17:o18]
+		items0, err := ssz.SplitOffsets(buf, 1024)
+		if err != nil {
+			return err
+		}
+		m.PreviousEpochAttestations = make([]MinimalPendingAttestation, len(items0))
+		for i0 := range items0 {
+			if err = m.PreviousEpochAttestations[i0].UnmarshalSSZ(items0[i0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (18) 'CurrentEpochAttestations'
+	{
+		buf := buf[o18:]
+		items0, err := ssz.SplitOffsets(buf, 1024)
+		if err != nil {
+			return err
+		}
+		m.CurrentEpochAttestations = make([]MinimalPendingAttestation, len(items0))
+		for i0 := range items0 {
+			if err = m.CurrentEpochAttestations[i0].UnmarshalSSZ(items0[i0]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// HashTreeRoot ssz hashes the MinimalBeaconState object.
+func (m *MinimalBeaconState) HashTreeRoot() ([32]byte, error) {
+	if m == nil {
+		m = new(MinimalBeaconState)
+	}
+	var err error
+	roots := make([][]byte, 0, 25)
+
+	// Field (0) 'GenesisTime'
+	var root0 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.GenesisTime)
+		if root0, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root0[:])
+
+	// Field (1) 'Slot'
+	var root1 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Slot)
+		if root1, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root1[:])
+
+	// Field (2) 'Fork'
+	var root2 [32]byte
+	if root2, err = m.Fork.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root2[:])
+
+	// Field (3) 'LatestBlockHeader'
+	var root3 [32]byte
+	if root3, err = m.LatestBlockHeader.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root3[:])
+
+	// Field (4) 'BlockRoots'
+	var root4 [32]byte
+	if len(m.BlockRoots) != 64 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.BlockRoots))
+		for i0 := range m.BlockRoots {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.BlockRoots[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root4, err = ssz.Merkleize(roots0, 64); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root4[:])
+
+	// Field (5) 'StateRoots'
+	var root5 [32]byte
+	if len(m.StateRoots) != 64 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.StateRoots))
+		for i0 := range m.StateRoots {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.StateRoots[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root5, err = ssz.Merkleize(roots0, 64); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root5[:])
+
+	// Field (6) 'HistoricalRoots'
+	var root6 [32]byte
+	if uint64(len(m.HistoricalRoots)) > 16777216 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.HistoricalRoots))
+		for i0 := range m.HistoricalRoots {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.HistoricalRoots[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root6, err = ssz.Merkleize(roots0, 16777216); err != nil {
+			return [32]byte{}, err
+		}
+		root6 = ssz.MixInLength(root6, uint64(len(m.HistoricalRoots)))
+	}
+	roots = append(roots, root6[:])
+
+	// Field (7) 'Eth1Data'
+	var root7 [32]byte
+	if root7, err = m.Eth1Data.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root7[:])
+
+	// Field (8) 'Eth1DataVotes'
+	var root8 [32]byte
+	if uint64(len(m.Eth1DataVotes)) > 16 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.Eth1DataVotes))
+		for i0 := range m.Eth1DataVotes {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.Eth1DataVotes[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root8, err = ssz.Merkleize(roots0, 16); err != nil {
+			return [32]byte{}, err
+		}
+		root8 = ssz.MixInLength(root8, uint64(len(m.Eth1DataVotes)))
+	}
+	roots = append(roots, root8[:])
+
+	// Field (9) 'Eth1DepositIndex'
+	var root9 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.Eth1DepositIndex)
+		if root9, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root9[:])
+
+	// Field (10) 'Validators'
+	var root10 [32]byte
+	if uint64(len(m.Validators)) > 1099511627776 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.Validators))
+		for i0 := range m.Validators {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.Validators[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root10, err = ssz.Merkleize(roots0, 1099511627776); err != nil {
+			return [32]byte{}, err
+		}
+		root10 = ssz.MixInLength(root10, uint64(len(m.Validators)))
+	}
+	roots = append(roots, root10[:])
+
+	// Field (11) 'Balances'
+	var root11 [32]byte
+	{
+		var buf0 []byte
+		if uint64(len(m.Balances)) > 1099511627776 {
+			return [32]byte{}, ssz.ErrListTooBig
+		}
+		for i0 := range m.Balances {
+			buf0 = ssz.MarshalUint64(buf0, m.Balances[i0])
+		}
+		if root11, err = ssz.Merkleize(ssz.Pack(buf0), 274877906944); err != nil {
+			return [32]byte{}, err
+		}
+		root11 = ssz.MixInLength(root11, uint64(len(m.Balances)))
+	}
+	roots = append(roots, root11[:])
+
+	// Field (12) 'StartShard'
+	var root12 [32]byte
+	{
+		var buf0 []byte
+		buf0 = ssz.MarshalUint64(buf0, m.StartShard)
+		if root12, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root12[:])
+
+	// Field (13) 'RandaoMixes'
+	var root13 [32]byte
+	if len(m.RandaoMixes) != 64 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.RandaoMixes))
+		for i0 := range m.RandaoMixes {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.RandaoMixes[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root13, err = ssz.Merkleize(roots0, 64); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root13[:])
+
+	// Field (14) 'ActiveIndexRoots'
+	var root14 [32]byte
+	if len(m.ActiveIndexRoots) != 64 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.ActiveIndexRoots))
+		for i0 := range m.ActiveIndexRoots {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.ActiveIndexRoots[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root14, err = ssz.Merkleize(roots0, 64); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root14[:])
+
+	// Field (15) 'CompactCommitteesRoots'
+	var root15 [32]byte
+	if len(m.CompactCommitteesRoots) != 64 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.CompactCommitteesRoots))
+		for i0 := range m.CompactCommitteesRoots {
+			var elemRoot0 [32]byte
+			{
+				var buf1 []byte
+				if buf1, err = ssz.MarshalFixedBytes(buf1, m.CompactCommitteesRoots[i0], 32); err != nil {
+					return [32]byte{}, err
+				}
+				if elemRoot0, err = ssz.Merkleize(ssz.Pack(buf1), 1); err != nil {
+					return [32]byte{}, err
+				}
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root15, err = ssz.Merkleize(roots0, 64); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root15[:])
+
+	// Field (16) 'Slashings'
+	var root16 [32]byte
+	{
+		var buf0 []byte
+		if len(m.Slashings) != 64 {
+			return [32]byte{}, ssz.ErrVectorLength
+		}
+		for i0 := range m.Slashings {
+			buf0 = ssz.MarshalUint64(buf0, m.Slashings[i0])
+		}
+		if root16, err = ssz.Merkleize(ssz.Pack(buf0), 16); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root16[:])
+
+	// Field (17) 'PreviousEpochAttestations'
+	var root17 [32]byte
+	if uint64(len(m.PreviousEpochAttestations)) > 1024 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.PreviousEpochAttestations))
+		for i0 := range m.PreviousEpochAttestations {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.PreviousEpochAttestations[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root17, err = ssz.Merkleize(roots0, 1024); err != nil {
+			return [32]byte{}, err
+		}
+		root17 = ssz.MixInLength(root17, uint64(len(m.PreviousEpochAttestations)))
+	}
+	roots = append(roots, root17[:])
+
+	// Field (18) 'CurrentEpochAttestations'
+	var root18 [32]byte
+	if uint64(len(m.CurrentEpochAttestations)) > 1024 {
+		return [32]byte{}, ssz.ErrListTooBig
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.CurrentEpochAttestations))
+		for i0 := range m.CurrentEpochAttestations {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.CurrentEpochAttestations[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root18, err = ssz.Merkleize(roots0, 1024); err != nil {
+			return [32]byte{}, err
+		}
+		root18 = ssz.MixInLength(root18, uint64(len(m.CurrentEpochAttestations)))
+	}
+	roots = append(roots, root18[:])
+
+	// Field (19) 'PreviousCrosslinks'
+	var root19 [32]byte
+	if len(m.PreviousCrosslinks) != 8 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.PreviousCrosslinks))
+		for i0 := range m.PreviousCrosslinks {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.PreviousCrosslinks[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root19, err = ssz.Merkleize(roots0, 8); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root19[:])
+
+	// Field (20) 'CurrentCrosslinks'
+	var root20 [32]byte
+	if len(m.CurrentCrosslinks) != 8 {
+		return [32]byte{}, ssz.ErrVectorLength
+	}
+	{
+		roots0 := make([][]byte, 0, len(m.CurrentCrosslinks))
+		for i0 := range m.CurrentCrosslinks {
+			var elemRoot0 [32]byte
+			if elemRoot0, err = m.CurrentCrosslinks[i0].HashTreeRoot(); err != nil {
+				return [32]byte{}, err
+			}
+			roots0 = append(roots0, elemRoot0[:])
+		}
+		if root20, err = ssz.Merkleize(roots0, 8); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root20[:])
+
+	// Field (21) 'JustificationBits'
+	var root21 [32]byte
+	{
+		var buf0 []byte
+		if buf0, err = ssz.MarshalFixedBytes(buf0, m.JustificationBits, 1); err != nil {
+			return [32]byte{}, err
+		}
+		if root21, err = ssz.Merkleize(ssz.Pack(buf0), 1); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	roots = append(roots, root21[:])
+
+	// Field (22) 'PreviousJustifiedCheckpoint'
+	var root22 [32]byte
+	if root22, err = m.PreviousJustifiedCheckpoint.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root22[:])
+
+	// Field (23) 'CurrentJustifiedCheckpoint'
+	var root23 [32]byte
+	if root23, err = m.CurrentJustifiedCheckpoint.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root23[:])
+
+	// Field (24) 'FinalizedCheckpoint'
+	var root24 [32]byte
+	if root24, err = m.FinalizedCheckpoint.HashTreeRoot(); err != nil {
+		return [32]byte{}, err
+	}
+	roots = append(roots, root24[:])
+	return ssz.Merkleize(roots, 25)
+}
@@ -0,0 +1,124 @@
+package generated
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	ssz "github.com/prysmaticlabs/go-ssz"
+	autogenerated "github.com/prysmaticlabs/go-ssz/spectests"
+)
+
+type sszMarshaler interface {
+	MarshalSSZ() ([]byte, error)
+	UnmarshalSSZ([]byte) error
+	HashTreeRoot() ([32]byte, error)
+}
+
+func TestGeneratedBlockMatchesReflection(t *testing.T) {
+	s := &struct {
+		Value      MinimalBlock `json:"value"`
+		Serialized []byte       `json:"serialized"`
+	}{}
+	populateStructFromYaml(t, "../yaml/ssz_single_block.yaml", s)
+	reflected := &struct {
+		Value autogenerated.MinimalBlock `json:"value"`
+	}{}
+	populateStructFromYaml(t, "../yaml/ssz_single_block.yaml", reflected)
+	compareWithReflection(t, &s.Value, new(MinimalBlock), &reflected.Value, s.Serialized, nil)
+}
+
+func TestGeneratedStateMatchesReflection(t *testing.T) {
+	s := &struct {
+		Value      MinimalBeaconState `json:"value"`
+		Serialized []byte             `json:"serialized"`
+		Root       []byte             `json:"root"`
+	}{}
+	populateStructFromYaml(t, "../yaml/ssz_single_state.yaml", s)
+	reflected := &struct {
+		Value autogenerated.MinimalBeaconState `json:"value"`
+	}{}
+	populateStructFromYaml(t, "../yaml/ssz_single_state.yaml", reflected)
+	compareWithReflection(t, &s.Value, new(MinimalBeaconState), &reflected.Value, s.Serialized, s.Root)
+}
+
+func TestGeneratedUnmarshal_RejectsMalformedInput(t *testing.T) {
+	checkpoint := &MinimalCheckpoint{Epoch: 5, Root: make([]byte, 32)}
+	encoded, err := checkpoint.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(MinimalCheckpoint).UnmarshalSSZ(encoded[1:]); err != ssz.ErrIncorrectSize {
+		t.Errorf("Expected %v, received %v", ssz.ErrIncorrectSize, err)
+	}
+	exit := &MinimalIndexedAttestation{CustodyBit0Indices: []uint64{1, 2}}
+	encoded, err = exit.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Corrupt the first offset so it points past the fixed part.
+	encoded[0] = 0xff
+	if err := new(MinimalIndexedAttestation).UnmarshalSSZ(encoded); err != ssz.ErrInvalidOffset {
+		t.Errorf("Expected %v, received %v", ssz.ErrInvalidOffset, err)
+	}
+}
+
+func TestGeneratedMarshal_EnforcesListLimits(t *testing.T) {
+	body := &MinimalBlockBody{AttesterSlashings: make([]MinimalAttesterSlashing, 2)}
+	if _, err := body.MarshalSSZ(); err != ssz.ErrListTooBig {
+		t.Errorf("Expected %v, received %v", ssz.ErrListTooBig, err)
+	}
+	if _, err := body.HashTreeRoot(); err != ssz.ErrListTooBig {
+		t.Errorf("Expected %v, received %v", ssz.ErrListTooBig, err)
+	}
+}
+
+// compareWithReflection checks the generated methods of val against the reflective
+// engine applied to reflectVal, a copy of the same value whose type has no methods.
+func compareWithReflection(t *testing.T, val sszMarshaler, target sszMarshaler, reflectVal interface{}, serialized []byte, expectedRoot []byte) {
+	encoded, err := val.MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, serialized) {
+		t.Fatal("Generated marshaler did not match the expected encoding")
+	}
+	reflected, err := ssz.Marshal(reflectVal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, reflected) {
+		t.Fatal("Generated marshaler did not match the reflective marshaler")
+	}
+	if err := target.UnmarshalSSZ(encoded); err != nil {
+		t.Fatal(err)
+	}
+	if !ssz.DeepEqual(target, val) {
+		t.Errorf("Generated unmarshaler did not match original value:\n%s", ssz.FormatDiff(ssz.Diff(target, val)))
+	}
+	root, err := val.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reflectedRoot, err := ssz.HashTreeRoot(reflectVal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != reflectedRoot {
+		t.Errorf("Expected hash tree root %#x, received %#x", reflectedRoot, root)
+	}
+	if expectedRoot != nil && !bytes.Equal(root[:], expectedRoot) {
+		t.Errorf("Expected hash tree root %#x, received %#x", expectedRoot, root)
+	}
+}
+
+func populateStructFromYaml(t testing.TB, fPath string, val interface{}) {
+	yamlFile, err := ioutil.ReadFile(fPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(yamlFile, val); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+}
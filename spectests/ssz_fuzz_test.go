@@ -0,0 +1,194 @@
+//go:build go1.18
+// +build go1.18
+
+package autogenerated
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// maxFuzzDecodeSize bounds how much the stream decoder may read from a fuzzed input.
+const maxFuzzDecodeSize = 1 << 20
+
+// fuzzUnmarshal fuzzes the decoding of values of the type of zero. Unmarshal, in both
+// strict and lenient mode, and the stream decoder must return an error instead of
+// panicking on any input, and any input which strict Unmarshal accepts must encode
+// back to itself. The corpus is seeded with the encodings of seeds, on top of the
+// inputs under testdata/fuzz.
+func fuzzUnmarshal(f *testing.F, zero interface{}, seeds ...interface{}) {
+	typ := reflect.TypeOf(zero)
+	for _, seed := range append([]interface{}{zero}, seeds...) {
+		encoded, err := ssz.Marshal(seed)
+		if err != nil {
+			f.Fatalf("Could not marshal seed %v: %v", seed, err)
+		}
+		f.Add(encoded)
+	}
+	f.Fuzz(func(t *testing.T, input []byte) {
+		ssz.ToggleStrictUnmarshal(false)
+		if err := ssz.Unmarshal(input, reflect.New(typ).Interface()); err != nil {
+			t.Logf("Lenient unmarshal failed: %v", err)
+		}
+		ssz.ToggleStrictUnmarshal(true)
+
+		decoded := reflect.New(typ).Interface()
+		if err := ssz.NewDecoder(bytes.NewReader(input), maxFuzzDecodeSize).Decode(decoded); err != nil {
+			t.Logf("Decode failed: %v", err)
+		}
+
+		val := reflect.New(typ)
+		if err := ssz.Unmarshal(input, val.Interface()); err != nil {
+			return
+		}
+		encoded, err := ssz.Marshal(val.Elem().Interface())
+		if err != nil {
+			t.Fatalf("Could not marshal accepted input %#x: %v", input, err)
+		}
+		if !bytes.Equal(encoded, input) {
+			t.Fatalf("Accepted input %#x encodes back to %#x", input, encoded)
+		}
+		if _, err := ssz.HashTreeRoot(val.Elem().Interface()); err != nil {
+			t.Logf("Could not tree hash accepted input: %v", err)
+		}
+	})
+}
+
+func FuzzMinimalFork(f *testing.F) {
+	fuzzUnmarshal(f, MinimalFork{})
+}
+
+func FuzzMinimalCheckpoint(f *testing.F) {
+	fuzzUnmarshal(f, MinimalCheckpoint{})
+}
+
+func FuzzMinimalValidator(f *testing.F) {
+	fuzzUnmarshal(f, MinimalValidator{})
+}
+
+func FuzzMinimalCrosslink(f *testing.F) {
+	fuzzUnmarshal(f, MinimalCrosslink{})
+}
+
+func FuzzMinimalAttestationData(f *testing.F) {
+	fuzzUnmarshal(f, MinimalAttestationData{})
+}
+
+func FuzzMinimalIndexedAttestation(f *testing.F) {
+	fuzzUnmarshal(f, MinimalIndexedAttestation{}, MinimalIndexedAttestation{
+		CustodyBit0Indices: []uint64{1, 2, 3},
+		CustodyBit1Indices: []uint64{4},
+		Signature:          make([]byte, 96),
+	})
+}
+
+func FuzzMinimalPendingAttestation(f *testing.F) {
+	fuzzUnmarshal(f, MinimalPendingAttestation{}, MinimalPendingAttestation{
+		AggregationBits: []byte{0x0b},
+		InclusionDelay:  1,
+	})
+}
+
+func FuzzMinimalEth1Data(f *testing.F) {
+	fuzzUnmarshal(f, MinimalEth1Data{})
+}
+
+func FuzzMinimalHistoricalBatch(f *testing.F) {
+	fuzzUnmarshal(f, MinimalHistoricalBatch{}, MinimalHistoricalBatch{
+		BlockRoots: fuzzRoots(64),
+		StateRoots: fuzzRoots(64),
+	})
+}
+
+func FuzzMinimalDepositData(f *testing.F) {
+	fuzzUnmarshal(f, MinimalDepositData{})
+}
+
+func FuzzMinimalCompactCommittee(f *testing.F) {
+	fuzzUnmarshal(f, MinimalCompactCommittee{}, MinimalCompactCommittee{
+		Pubkeys:           [][]byte{make([]byte, 48), make([]byte, 48)},
+		CompactValidators: []uint64{7, 8},
+	})
+}
+
+func FuzzMinimalBlockHeader(f *testing.F) {
+	fuzzUnmarshal(f, MinimalBlockHeader{})
+}
+
+func FuzzMinimalProposerSlashing(f *testing.F) {
+	fuzzUnmarshal(f, MinimalProposerSlashing{})
+}
+
+func FuzzMinimalAttesterSlashing(f *testing.F) {
+	fuzzUnmarshal(f, MinimalAttesterSlashing{})
+}
+
+func FuzzMinimalAttestation(f *testing.F) {
+	fuzzUnmarshal(f, MinimalAttestation{}, MinimalAttestation{
+		AggregationBits: []byte{0x05},
+		CustodyBits:     []byte{0x04},
+		Signature:       make([]byte, 96),
+	})
+}
+
+func FuzzMinimalDeposit(f *testing.F) {
+	fuzzUnmarshal(f, MinimalDeposit{}, MinimalDeposit{Proof: fuzzRoots(33)})
+}
+
+func FuzzMinimalVoluntaryExit(f *testing.F) {
+	fuzzUnmarshal(f, MinimalVoluntaryExit{})
+}
+
+func FuzzMinimalTransfer(f *testing.F) {
+	fuzzUnmarshal(f, MinimalTransfer{})
+}
+
+func FuzzMinimalBlockBody(f *testing.F) {
+	fuzzUnmarshal(f, MinimalBlockBody{}, MinimalBlockBody{
+		RandaoReveal: make([]byte, 96),
+		Graffiti:     make([]byte, 32),
+		Attestations: []MinimalAttestation{{
+			AggregationBits: []byte{0x03},
+			CustodyBits:     []byte{0x02},
+			Signature:       make([]byte, 96),
+		}},
+		VoluntaryExits: []MinimalVoluntaryExit{{Epoch: 1, Signature: make([]byte, 96)}},
+	})
+}
+
+func FuzzMinimalBlock(f *testing.F) {
+	fuzzUnmarshal(f, MinimalBlock{})
+}
+
+func FuzzMinimalBeaconState(f *testing.F) {
+	fuzzUnmarshal(f, MinimalBeaconState{}, MinimalBeaconState{
+		BlockRoots:             fuzzRoots(64),
+		StateRoots:             fuzzRoots(64),
+		HistoricalRoots:        fuzzRoots(2),
+		Eth1DataVotes:          []MinimalEth1Data{{DepositCount: 1}},
+		Validators:             []MinimalValidator{{EffectiveBalance: 32, Slashed: true}},
+		Balances:               []uint64{32},
+		RandaoMixes:            fuzzRoots(64),
+		ActiveIndexRoots:       fuzzRoots(64),
+		CompactCommitteesRoots: fuzzRoots(64),
+		Slashings:              make([]uint64, 64),
+		PreviousEpochAttestations: []MinimalPendingAttestation{
+			{AggregationBits: []byte{0x01}},
+		},
+		PreviousCrosslinks: make([]MinimalCrosslink, 8),
+		CurrentCrosslinks:  make([]MinimalCrosslink, 8),
+		JustificationBits:  []byte{0x00},
+	})
+}
+
+// fuzzRoots returns n zero roots, to fill vectors of roots in seeds.
+func fuzzRoots(n int) [][]byte {
+	roots := make([][]byte, n)
+	for i := range roots {
+		roots[i] = make([]byte, 32)
+	}
+	return roots
+}
@@ -39,7 +39,7 @@ func TestYamlStateRoundTrip(t *testing.T) {
 		t.Fatal(err)
 	}
 	if !ssz.DeepEqual(targetState, s.Value) {
-		t.Error("Unmarshaled encoding did not match original value")
+		t.Errorf("Unmarshaled encoding did not match original value:\n%s", ssz.FormatDiff(ssz.Diff(targetState, s.Value)))
 	}
 }
 
@@ -58,13 +58,58 @@ func TestYamlBlockRoundTrip(t *testing.T) {
 		t.Fatal(err)
 	}
 	if !ssz.DeepEqual(targetBlock, s.Value) {
-		t.Error("Unmarshaled encoding did not match original value")
+		t.Errorf("Unmarshaled encoding did not match original value:\n%s", ssz.FormatDiff(ssz.Diff(targetBlock, s.Value)))
 	}
 	if _, err := ssz.HashTreeRoot(s.Value); err != nil {
 		t.Fatal(err)
 	}
 }
 
+// signingRootTest holds compute_domain and compute_signing_root vectors.
+type signingRootTest struct {
+	Domains []struct {
+		DomainType            []byte `json:"domain_type"`
+		ForkVersion           []byte `json:"fork_version"`
+		GenesisValidatorsRoot []byte `json:"genesis_validators_root"`
+		Domain                []byte `json:"domain"`
+	} `json:"domains"`
+	SigningRoots []struct {
+		Value       MinimalCheckpoint `json:"value"`
+		Domain      []byte            `json:"domain"`
+		SigningRoot []byte            `json:"signing_root"`
+	} `json:"signing_roots"`
+}
+
+func TestYamlSigningRoots(t *testing.T) {
+	s := &signingRootTest{}
+	populateStructFromYaml(t, "./yaml/signing_root.yaml", s)
+	for i, tt := range s.Domains {
+		var domainType, forkVersion [4]byte
+		var genesisValidatorsRoot [32]byte
+		copy(domainType[:], tt.DomainType)
+		copy(forkVersion[:], tt.ForkVersion)
+		copy(genesisValidatorsRoot[:], tt.GenesisValidatorsRoot)
+		domain, err := ssz.ComputeDomain(domainType, forkVersion, genesisValidatorsRoot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(domain[:], tt.Domain) {
+			t.Errorf("Domain %d: expected %#x, received %#x", i, tt.Domain, domain)
+		}
+	}
+	for i, tt := range s.SigningRoots {
+		var domain [32]byte
+		copy(domain[:], tt.Domain)
+		root, err := ssz.ComputeSigningRoot(tt.Value, domain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(root[:], tt.SigningRoot) {
+			t.Errorf("Signing root %d: expected %#x, received %#x", i, tt.SigningRoot, root)
+		}
+	}
+}
+
 func TestYamlGenericSpecTests(t *testing.T) {
 	topPath := "/eth2_spec_tests/tests/ssz_generic/uint/"
 	yamlFileNames := []string{
@@ -680,7 +725,7 @@ func compareSSZEncoding(t *testing.T, cfg *sszComparisonConfig) {
 	}
 	concreteValue := reflect.ValueOf(cfg.unmarshalTarget).Elem().Interface()
 	if !ssz.DeepEqual(concreteValue, cfg.val) {
-		t.Error("Unmarshaled encoding did not match original value")
+		t.Errorf("Unmarshaled encoding did not match original value:\n%s", ssz.FormatDiff(ssz.Diff(concreteValue, cfg.val)))
 	}
 	root, err := ssz.HashTreeRoot(cfg.val)
 	if err != nil {
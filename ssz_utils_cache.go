@@ -11,18 +11,26 @@ type marshaler func(reflect.Value, []byte, uint64) (uint64, error)
 
 type unmarshaler func([]byte, reflect.Value, uint64) (uint64, error)
 
-type hasher func(reflect.Value, uint64) ([32]byte, error)
+// The hasher type takes in a value, the maximum capacity of lists, and the context to
+// merkleize with, and returns the hash tree root of the value.
+type hasher func(reflect.Value, uint64, *hashContext) ([32]byte, error)
 
 type sszUtils struct {
 	marshaler
 	unmarshaler
 	hasher
+	// typ is the SSZ type encoded and hashed, which differs from the Go type of the
+	// values for fields whose tags give them another type, such as byte slices hashed
+	// as vectors.
+	typ reflect.Type
+	// hasRootCache is set for structs with a RootCache, and pointers to them, which
+	// are not looked up in the hash cache as they reuse the roots of their fields.
+	hasRootCache bool
 }
 
 var (
 	sszUtilsCacheMutex sync.RWMutex
 	sszUtilsCache      = make(map[reflect.Type]*sszUtils)
-	hashCache          = newHashCache(100000)
 )
 
 // Get cached encoder, encodeSizer and unmarshaler implementation for a specified type.
@@ -69,15 +77,39 @@ func cachedSSZUtilsNoAcquireLock(typ reflect.Type) (*sszUtils, error) {
 }
 
 func generateSSZUtilsForType(typ reflect.Type) (utils *sszUtils, err error) {
+	// Unions are structs in Go but a kind of their own in SSZ. Without an ssz-union
+	// tag their variants are unknown, so they are encoded using the type of their value.
+	if typ == unionType {
+		return makeUnionUtils(nil)
+	}
 	utils = new(sszUtils)
-	if utils.marshaler, err = makeMarshaler(typ); err != nil {
+	// Types implementing Marshaler, Unmarshaler or HashRoot are trusted to
+	// encode and hash themselves, the same way encoding/json honours json.Marshaler.
+	if implements(typ, marshalerType) {
+		utils.marshaler, err = makeCustomMarshaler(typ)
+	} else {
+		utils.marshaler, err = makeMarshaler(typ)
+	}
+	if err != nil {
 		return nil, err
 	}
-	if utils.unmarshaler, err = makeUnmarshaler(typ); err != nil {
+	if implements(typ, unmarshalerType) {
+		utils.unmarshaler, err = makeCustomUnmarshaler(typ)
+	} else {
+		utils.unmarshaler, err = makeUnmarshaler(typ)
+	}
+	if err != nil {
 		return nil, err
 	}
-	if utils.hasher, err = makeHasher(typ); err != nil {
+	if implements(typ, hashRootType) {
+		utils.hasher, err = makeCustomHasher(typ)
+	} else {
+		utils.hasher, err = makeHasher(typ)
+	}
+	if err != nil {
 		return nil, err
 	}
+	utils.hasRootCache = hasRootCache(typ)
+	utils.typ = typ
 	return utils, nil
 }
@@ -5,6 +5,9 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/prysmaticlabs/go-bitfield"
 )
 
 // UnboundedSSZFieldSizeMarker is the character used to specify a ssz field should have
@@ -13,6 +16,16 @@ import (
 // is chosen as the default value given its simplicity to represent unbounded size.
 var UnboundedSSZFieldSizeMarker = "?"
 
+// Options of the ssz struct tag, which lists them separated by commas.
+const (
+	// omitTagOption excludes a field from the SSZ value entirely, the way `json:"-"`
+	// excludes it from JSON.
+	omitTagOption = "-"
+	// signatureTagOption marks the signature of a signed struct, which is part of its
+	// value but is excluded from its signing root.
+	signatureTagOption = "signature"
+)
+
 // field defines a custom wrapper around a struct field which
 // include the respective sszUtils for that particular field type,
 // giving easy access to its marshaler, unmarshaler, and tree hasher.
@@ -23,21 +36,33 @@ type field struct {
 	sszUtils    *sszUtils
 	capacity    uint64
 	hasCapacity bool
+	// signature is set for fields tagged `ssz:"signature"`.
+	signature bool
 }
 
-// truncateLast removes the last value of a struct, usually the signature,
-// in order to hash only the data the signature field is intended to represent.
-func truncateLast(typ reflect.Type) (fields []field, err error) {
-	fields, err = structFields(typ)
-	if err != nil {
-		return nil, err
+// hasTagOption reports whether the ssz tag of the struct field f lists option.
+func hasTagOption(f reflect.StructField, option string) bool {
+	tag, exists := f.Tag.Lookup("ssz")
+	if !exists {
+		return false
+	}
+	for _, o := range strings.Split(tag, ",") {
+		if strings.TrimSpace(o) == option {
+			return true
+		}
 	}
-	return fields[:len(fields)-1], nil
+	return false
+}
+
+// isOmittedField reports whether the struct field f is not part of the SSZ value,
+// either because it is a RootCache or because it is tagged `ssz:"-"`.
+func isOmittedField(f reflect.StructField) bool {
+	return f.Type == rootCacheType || hasTagOption(f, omitTagOption)
 }
 
 // structFields iterates over the raw fields of a struct, ignoring XXX protobuf fields,
-// and determines the necessary ssz utils such as the marshaler, unmarshaler, and tree hasher
-// for that particular struct field. Then, it returns a slice of field wrappers containing
+// RootCache members and fields tagged `ssz:"-"`, and determines the necessary ssz utils such as the marshaler,
+// unmarshaler, and tree hasher for that particular struct field. Then, it returns a slice of field wrappers containing
 // the necessary SSZ utils and field type information.
 func structFields(typ reflect.Type) (fields []field, err error) {
 	if typ.Kind() != reflect.Struct {
@@ -45,7 +70,7 @@ func structFields(typ reflect.Type) (fields []field, err error) {
 	}
 	for i := 0; i < typ.NumField(); i++ {
 		f := typ.Field(i)
-		if strings.Contains(f.Name, "XXX") {
+		if strings.Contains(f.Name, "XXX") || isOmittedField(f) {
 			continue
 		}
 		// determineFieldType parses the struct's tags to check if there are any ssz tags
@@ -58,9 +83,25 @@ func structFields(typ reflect.Type) (fields []field, err error) {
 
 		// We determine the SSZ utils for the field, including its respective
 		// marshaler, unmarshaler, and hasher.
-		utils, err := cachedSSZUtilsNoAcquireLock(fType)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get ssz utils: %v", err)
+		var utils *sszUtils
+		if f.Type == bigIntType && isBasicUintType(fType) {
+			utils = makeBigIntUtils(fType)
+		} else if tag, exists := f.Tag.Lookup("ssz-union"); exists {
+			if f.Type != unionType {
+				return nil, fmt.Errorf("ssz-union tag is only supported on ssz.Union fields, field %s has type %v", f.Name, f.Type)
+			}
+			variants, err := parseUnionVariants(tag)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ssz-union tag on field %s: %v", f.Name, err)
+			}
+			if utils, err = makeUnionUtils(variants); err != nil {
+				return nil, err
+			}
+		} else {
+			utils, err = cachedSSZUtilsNoAcquireLock(fType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ssz utils: %v", err)
+			}
 		}
 		name := f.Name
 		fields = append(fields, field{
@@ -70,12 +111,114 @@ func structFields(typ reflect.Type) (fields []field, err error) {
 			typ:         fType,
 			capacity:    fCapacity,
 			hasCapacity: hasCapacity,
+			signature:   hasTagOption(f, signatureTagOption),
 		})
 	}
 	return fields, nil
 }
 
+// structFieldsCache holds the fields of the struct types looked up by cachedStructFields.
+var structFieldsCache sync.Map
+
+// cachedStructFields returns the fields of the struct type typ, computing them once
+// under the lock of the ssz utils cache that structFields fills. It must not be called
+// while holding that lock, such as when generating ssz utils.
+func cachedStructFields(typ reflect.Type) ([]field, error) {
+	if fields, ok := structFieldsCache.Load(typ); ok {
+		return fields.([]field), nil
+	}
+	sszUtilsCacheMutex.Lock()
+	fields, err := structFields(typ)
+	sszUtilsCacheMutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	structFieldsCache.Store(typ, fields)
+	return fields, nil
+}
+
+// checkFieldCapacity checks that the list, bitlist or byte slice val held in field f
+// does not hold more items than the ssz-max tag of the field allows.
+func checkFieldCapacity(val reflect.Value, f field) error {
+	if !f.hasCapacity {
+		return nil
+	}
+	length := uint64(0)
+	switch {
+	case val.Type() == bitlistType:
+		if val.Len() > 0 {
+			length = val.Interface().(bitfield.Bitlist).Len()
+		}
+	case val.Kind() == reflect.Slice:
+		length = uint64(val.Len())
+	default:
+		return nil
+	}
+	return capacityError(length, f.capacity)
+}
+
+// checkEncodedCapacity checks that the encoding of the list, bitlist or byte slice
+// held in field f does not hold more items than the ssz-max tag of the field allows,
+// before any of its items are decoded.
+func checkEncodedCapacity(input []byte, f field) error {
+	if !f.hasCapacity {
+		return nil
+	}
+	size := uint64(len(input))
+	switch {
+	case f.typ == bitlistType:
+		if size > 0 && input[size-1] != 0 {
+			return capacityError(bitfield.Bitlist(input).Len(), f.capacity)
+		}
+		return nil
+	case f.typ.Kind() == reflect.Slice && isVariableSizeType(f.typ.Elem()) && size >= BytesPerLengthOffset:
+		return capacityError(ReadOffset(input)/BytesPerLengthOffset, f.capacity)
+	}
+	return checkSizeCapacity(size, f)
+}
+
+// checkSizeCapacity checks, from the size of its encoding alone, that the list, bitlist
+// or byte slice held in field f does not hold more items than the ssz-max tag of the
+// field allows. Bitlists are checked against the fewest items their size allows, and
+// lists of variable-size items are checked by the Decoder once their first offset is
+// read.
+func checkSizeCapacity(size uint64, f field) error {
+	if !f.hasCapacity {
+		return nil
+	}
+	length := uint64(0)
+	switch {
+	case f.typ == bitlistType:
+		// The length bit is in the last byte, so every other byte holds 8 items.
+		if size > 0 {
+			length = (size - 1) * 8
+		}
+	case f.typ.Kind() != reflect.Slice:
+		return nil
+	case f.typ.Elem().Kind() == reflect.Uint8:
+		length = size
+	case !isVariableSizeType(f.typ.Elem()):
+		if elemSize := fixedTypeSize(f.typ.Elem()); elemSize > 0 {
+			length = size / elemSize
+		}
+	}
+	return capacityError(length, f.capacity)
+}
+
+// capacityError returns an error wrapping ErrListTooBig if length items are more
+// than capacity allows.
+func capacityError(length uint64, capacity uint64) error {
+	if length > capacity {
+		return fmt.Errorf("%d items exceed the ssz-max of %d: %w", length, capacity, ErrListTooBig)
+	}
+	return nil
+}
+
 func determineFieldType(field reflect.StructField) (reflect.Type, error) {
+	// Big integers declare the basic type they are encoded as through an ssz-type tag.
+	if tag, exists := field.Tag.Lookup("ssz-type"); exists {
+		return bigIntFieldType(field, tag)
+	}
 	fieldSizeTags, exists, err := parseSSZFieldTags(field)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse ssz struct field tags: %v", err)
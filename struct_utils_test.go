@@ -79,3 +79,46 @@ func TestParseSSZFieldTags_HandlesOverflow(t *testing.T) {
 		t.Errorf("got: %d, wanted %d", result, want)
 	}
 }
+
+type omittedFieldCase struct {
+	Slot    uint64
+	Scratch map[string]int `ssz:"-"`
+	Root    []byte
+}
+
+func TestStructFields_OmitTag(t *testing.T) {
+	val := omittedFieldCase{Slot: 3, Scratch: map[string]int{"a": 1}, Root: []byte{1, 2}}
+	plain := struct {
+		Slot uint64
+		Root []byte
+	}{Slot: 3, Root: []byte{1, 2}}
+	encoded, err := Marshal(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := Marshal(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(encoded, want) {
+		t.Errorf("Marshal() = %#x, want %#x", encoded, want)
+	}
+	root, err := HashTreeRoot(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRoot, err := HashTreeRoot(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != wantRoot {
+		t.Errorf("HashTreeRoot() = %#x, want %#x", root, wantRoot)
+	}
+	decoded := omittedFieldCase{}
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Scratch != nil || !DeepEqual(decoded, val) {
+		t.Errorf("Unmarshal() = %+v, want %+v without its omitted field", decoded, val)
+	}
+}
@@ -0,0 +1,130 @@
+package ssz
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// Uint128 is the SSZ uint128 basic type, stored as its 16 byte little-endian encoding.
+type Uint128 [16]byte
+
+// Uint256 is the SSZ uint256 basic type, stored as its 32 byte little-endian encoding.
+type Uint256 [32]byte
+
+var (
+	uint128Type = reflect.TypeOf(Uint128{})
+	uint256Type = reflect.TypeOf(Uint256{})
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+)
+
+// Uint128FromBig converts a big.Int into a Uint128, returning an error if it
+// is negative or does not fit in 128 bits.
+func Uint128FromBig(b *big.Int) (Uint128, error) {
+	var u Uint128
+	if err := putBigInt(u[:], b); err != nil {
+		return Uint128{}, err
+	}
+	return u, nil
+}
+
+// Big returns the value of u as a big.Int.
+func (u Uint128) Big() *big.Int {
+	return bigIntFromLittleEndian(u[:])
+}
+
+// Uint256FromBig converts a big.Int into a Uint256, returning an error if it
+// is negative or does not fit in 256 bits.
+func Uint256FromBig(b *big.Int) (Uint256, error) {
+	var u Uint256
+	if err := putBigInt(u[:], b); err != nil {
+		return Uint256{}, err
+	}
+	return u, nil
+}
+
+// Big returns the value of u as a big.Int.
+func (u Uint256) Big() *big.Int {
+	return bigIntFromLittleEndian(u[:])
+}
+
+// isBasicUintType reports whether typ is Uint128 or Uint256, which are basic types
+// in SSZ even though they are backed by byte arrays.
+func isBasicUintType(typ reflect.Type) bool {
+	return typ == uint128Type || typ == uint256Type
+}
+
+// bigIntFieldType maps the value of an ssz-type tag on a *big.Int field to the
+// basic type it is encoded as.
+func bigIntFieldType(field reflect.StructField, tag string) (reflect.Type, error) {
+	if field.Type != bigIntType {
+		return nil, fmt.Errorf("ssz-type tag is only supported on *big.Int fields, field %s has type %v", field.Name, field.Type)
+	}
+	switch tag {
+	case "uint128":
+		return uint128Type, nil
+	case "uint256":
+		return uint256Type, nil
+	default:
+		return nil, fmt.Errorf("unsupported ssz-type %q on field %s", tag, field.Name)
+	}
+}
+
+// putBigInt writes b into dst in little-endian order, zero padding the remaining bytes.
+// A nil value is written as zero.
+func putBigInt(dst []byte, b *big.Int) error {
+	for i := range dst {
+		dst[i] = 0
+	}
+	if b == nil {
+		return nil
+	}
+	if b.Sign() < 0 {
+		return fmt.Errorf("cannot encode negative value %v as uint%d", b, len(dst)*8)
+	}
+	if b.BitLen() > len(dst)*8 {
+		return fmt.Errorf("value %v overflows uint%d", b, len(dst)*8)
+	}
+	bigEndian := b.Bytes()
+	for i, v := range bigEndian {
+		dst[len(bigEndian)-1-i] = v
+	}
+	return nil
+}
+
+func bigIntFromLittleEndian(src []byte) *big.Int {
+	bigEndian := make([]byte, len(src))
+	for i, v := range src {
+		bigEndian[len(src)-1-i] = v
+	}
+	return new(big.Int).SetBytes(bigEndian)
+}
+
+// makeBigIntUtils returns the ssz utils for a *big.Int field encoded as the basic
+// type typ, which is either Uint128 or Uint256.
+func makeBigIntUtils(typ reflect.Type) *sszUtils {
+	size := uint64(typ.Len())
+	marshaler := func(val reflect.Value, buf []byte, startOffset uint64) (uint64, error) {
+		if err := putBigInt(buf[startOffset:startOffset+size], val.Interface().(*big.Int)); err != nil {
+			return 0, err
+		}
+		return startOffset + size, nil
+	}
+	unmarshaler := func(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
+		offset := startOffset + size
+		if offset > uint64(len(input)) {
+			return 0, fmt.Errorf("input of %d bytes is too short for uint%d", len(input), size*8)
+		}
+		val.Set(reflect.ValueOf(bigIntFromLittleEndian(input[startOffset:offset])))
+		return offset, nil
+	}
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		// A basic type fits in a single chunk, which is its own root.
+		var root [32]byte
+		if err := putBigInt(root[:size], val.Interface().(*big.Int)); err != nil {
+			return [32]byte{}, err
+		}
+		return root, nil
+	}
+	return &sszUtils{marshaler: marshaler, unmarshaler: unmarshaler, hasher: hasher, typ: typ}
+}
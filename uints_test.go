@@ -0,0 +1,162 @@
+package ssz_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/big"
+	"reflect"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type uintsContainer struct {
+	Small    ssz.Uint128
+	Large    ssz.Uint256
+	Balances []ssz.Uint128 `ssz-max:"4"`
+}
+
+type bigIntContainer struct {
+	Epoch   uint64
+	Balance *big.Int `ssz-type:"uint256"`
+	Fee     *big.Int `ssz-type:"uint128"`
+}
+
+func TestUint256FromBig(t *testing.T) {
+	value, ok := new(big.Int).SetString("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", 16)
+	if !ok {
+		t.Fatal("Could not parse value")
+	}
+	u, err := ssz.Uint256FromBig(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The encoding is little-endian, so the least significant byte comes first.
+	if u[0] != 0x20 || u[31] != 0x01 {
+		t.Errorf("Expected little-endian encoding, received %#x", u)
+	}
+	if u.Big().Cmp(value) != 0 {
+		t.Errorf("Expected %v, received %v", value, u.Big())
+	}
+}
+
+func TestUint128FromBig_Overflow(t *testing.T) {
+	overflow := new(big.Int).Lsh(big.NewInt(1), 128)
+	if _, err := ssz.Uint128FromBig(overflow); err == nil {
+		t.Error("Expected error converting a value which overflows uint128, received nil")
+	}
+	if _, err := ssz.Uint128FromBig(big.NewInt(-1)); err == nil {
+		t.Error("Expected error converting a negative value, received nil")
+	}
+}
+
+func TestMarshal_Uint128AndUint256(t *testing.T) {
+	val := uintsContainer{
+		Small:    ssz.Uint128{1},
+		Large:    ssz.Uint256{2},
+		Balances: []ssz.Uint128{{3}, {4}},
+	}
+	encoded, err := ssz.Marshal(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encoded) != 16+32+4+2*16 {
+		t.Fatalf("Expected encoding of %d bytes, received %d", 16+32+4+2*16, len(encoded))
+	}
+	var decoded uintsContainer
+	if err := ssz.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(val, decoded) {
+		t.Errorf("Expected %v, received %v", val, decoded)
+	}
+}
+
+func TestHashTreeRoot_Uint128ListIsPacked(t *testing.T) {
+	balances := []ssz.Uint128{{3}, {4}}
+	root, err := ssz.HashTreeRootWithCapacity(balances, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two uint128 values share a single chunk, and a limit of 4 values needs 2 chunks.
+	chunk := make([]byte, 32)
+	chunk[0] = 3
+	chunk[16] = 4
+	merkleRoot := sha256.Sum256(append(chunk, make([]byte, 32)...))
+	length := make([]byte, 32)
+	length[0] = 2
+	want := sha256.Sum256(append(merkleRoot[:], length...))
+	if root != want {
+		t.Errorf("Expected %#x, received %#x", want, root)
+	}
+}
+
+func TestBigIntField_RoundTrip(t *testing.T) {
+	balance, ok := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	if !ok {
+		t.Fatal("Could not parse value")
+	}
+	val := &bigIntContainer{Epoch: 1, Balance: balance, Fee: big.NewInt(300)}
+	encoded, err := ssz.Marshal(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := ssz.Uint256FromBig(balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	small, err := ssz.Uint128FromBig(big.NewInt(300))
+	if err != nil {
+		t.Fatal(err)
+	}
+	equivalent := struct {
+		Epoch   uint64
+		Balance ssz.Uint256
+		Fee     ssz.Uint128
+	}{1, large, small}
+	want, err := ssz.Marshal(equivalent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Expected %v, received %v", want, encoded)
+	}
+	decoded := &bigIntContainer{}
+	if err := ssz.Unmarshal(encoded, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Epoch != 1 || decoded.Balance.Cmp(balance) != 0 || decoded.Fee.Cmp(big.NewInt(300)) != 0 {
+		t.Errorf("Expected %v, received %v", val, decoded)
+	}
+	root, err := ssz.HashTreeRoot(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRoot, err := ssz.HashTreeRoot(equivalent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != wantRoot {
+		t.Errorf("Expected %#x, received %#x", wantRoot, root)
+	}
+}
+
+func TestBigIntField_Overflow(t *testing.T) {
+	val := &bigIntContainer{Balance: big.NewInt(1), Fee: new(big.Int).Lsh(big.NewInt(1), 128)}
+	if _, err := ssz.Marshal(val); err == nil {
+		t.Error("Expected error marshaling a value which overflows uint128, received nil")
+	}
+}
+
+func TestDeepEqual_BigIntFields(t *testing.T) {
+	// A nil big integer encodes as zero, so it is equal to zero.
+	if !ssz.DeepEqual(&bigIntContainer{Fee: big.NewInt(0)}, &bigIntContainer{Balance: big.NewInt(0)}) {
+		t.Error("Expected nil and zero big integers to be equal")
+	}
+	if !ssz.DeepEqual(&bigIntContainer{Balance: big.NewInt(300)}, &bigIntContainer{Balance: new(big.Int).SetBytes([]byte{1, 44})}) {
+		t.Error("Expected big integers holding the same value to be equal")
+	}
+	if ssz.DeepEqual(&bigIntContainer{Balance: big.NewInt(300)}, &bigIntContainer{Balance: big.NewInt(301)}) {
+		t.Error("Expected big integers holding different values to differ")
+	}
+}
@@ -0,0 +1,219 @@
+package ssz
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Union is the SSZ Union[T1, T2, ...] type. It is encoded as a selector byte
+// followed by the encoding of the chosen variant, and its root is the root of
+// that variant mixed in with the selector.
+//
+// The variants of a union struct field are declared in selector order with an
+// ssz-union tag, naming either a basic type or a type registered with
+// RegisterUnionType. The first variant may be None, in which case a zero
+// selector carries no value:
+//
+//  ssz.RegisterUnionType("Checkpoint", Checkpoint{})
+//
+//  type exampleStruct struct {
+//      Field1 ssz.Union `ssz-union:"None,uint64,Checkpoint"`
+//  }
+//
+// Unions which are not declared on a tagged field can be marshaled and hashed
+// using the type of their value, but not unmarshaled.
+type Union struct {
+	Selector uint8
+	Value    interface{}
+}
+
+// noneUnionVariant is the name of the variant which carries no value.
+const noneUnionVariant = "None"
+
+// maxUnionVariants is the number of selectors allowed by the specification.
+const maxUnionVariants = 128
+
+var (
+	// ErrUnknownSelector is returned when a union selector does not refer to one of its variants.
+	ErrUnknownSelector = errors.New("unknown union selector")
+
+	unionType = reflect.TypeOf(Union{})
+
+	unionTypesLock sync.RWMutex
+	unionTypes     = map[string]reflect.Type{
+		"bool":    reflect.TypeOf(false),
+		"uint8":   reflect.TypeOf(uint8(0)),
+		"uint16":  reflect.TypeOf(uint16(0)),
+		"uint32":  reflect.TypeOf(uint32(0)),
+		"uint64":  reflect.TypeOf(uint64(0)),
+		"uint128": uint128Type,
+		"uint256": uint256Type,
+	}
+)
+
+// RegisterUnionType records the type of value under the given name, so that it
+// can be listed as a variant in ssz-union tags. Like gob.Register, it panics if
+// the name is already registered to a different type.
+func RegisterUnionType(name string, value interface{}) {
+	if value == nil {
+		panic("ssz: cannot register nil union type")
+	}
+	if name == noneUnionVariant {
+		panic("ssz: union type name None is reserved")
+	}
+	typ := reflect.TypeOf(value)
+	unionTypesLock.Lock()
+	defer unionTypesLock.Unlock()
+	if existing, ok := unionTypes[name]; ok && existing != typ {
+		panic(fmt.Sprintf("ssz: registering duplicate union type %s for %v, already registered for %v", name, typ, existing))
+	}
+	unionTypes[name] = typ
+}
+
+// parseUnionVariants maps the names in an ssz-union tag to their types, where a
+// nil type stands for the None variant.
+func parseUnionVariants(tag string) ([]reflect.Type, error) {
+	names := strings.Split(tag, ",")
+	if len(names) > maxUnionVariants {
+		return nil, fmt.Errorf("union has %d variants, more than the maximum of %d", len(names), maxUnionVariants)
+	}
+	unionTypesLock.RLock()
+	defer unionTypesLock.RUnlock()
+	variants := make([]reflect.Type, len(names))
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if name == noneUnionVariant {
+			if i != 0 {
+				return nil, errors.New("only the first union variant can be None")
+			}
+			continue
+		}
+		typ, ok := unionTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("union variant %s is not a registered type", name)
+		}
+		variants[i] = typ
+	}
+	if len(variants) == 1 && variants[0] == nil {
+		return nil, errors.New("a union with a single variant cannot be None")
+	}
+	return variants, nil
+}
+
+// makeUnionUtils returns the ssz utils of a union with the given variants. If
+// variants is nil, the union is encoded using the type of its value.
+func makeUnionUtils(variants []reflect.Type) (*sszUtils, error) {
+	variantUtils := make([]*sszUtils, len(variants))
+	for i, typ := range variants {
+		if typ == nil {
+			continue
+		}
+		utils, err := cachedSSZUtilsNoAcquireLock(typ)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ssz utils for union variant %v: %v", typ, err)
+		}
+		variantUtils[i] = utils
+	}
+	// unionValue checks the value of a union against its variants, returning the value
+	// and its ssz utils, or an invalid value for the None variant.
+	unionValue := func(u Union) (reflect.Value, *sszUtils, error) {
+		value := reflect.ValueOf(u.Value)
+		if variants == nil {
+			if u.Value == nil {
+				if u.Selector != 0 {
+					return reflect.Value{}, nil, ErrUnknownSelector
+				}
+				return value, nil, nil
+			}
+			utils, err := cachedSSZUtils(value.Type())
+			if err != nil {
+				return reflect.Value{}, nil, err
+			}
+			return value, utils, nil
+		}
+		if int(u.Selector) >= len(variants) {
+			return reflect.Value{}, nil, ErrUnknownSelector
+		}
+		if variants[u.Selector] == nil {
+			if u.Value != nil {
+				return reflect.Value{}, nil, errors.New("none union variant cannot carry a value")
+			}
+			return value, nil, nil
+		}
+		if u.Value == nil || value.Type() != variants[u.Selector] {
+			return reflect.Value{}, nil, fmt.Errorf("union selector %d expects a value of type %v, received %T", u.Selector, variants[u.Selector], u.Value)
+		}
+		return value, variantUtils[u.Selector], nil
+	}
+
+	marshaler := func(val reflect.Value, buf []byte, startOffset uint64) (uint64, error) {
+		u := val.Interface().(Union)
+		value, utils, err := unionValue(u)
+		if err != nil {
+			return 0, err
+		}
+		buf[startOffset] = u.Selector
+		if utils == nil {
+			return startOffset + 1, nil
+		}
+		return utils.marshaler(value, buf, startOffset+1)
+	}
+	unmarshaler := func(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
+		if variants == nil {
+			return 0, errors.New("cannot unmarshal a union without an ssz-union tag declaring its variants")
+		}
+		if startOffset >= uint64(len(input)) {
+			return 0, errors.New("union is missing its selector")
+		}
+		selector := input[startOffset]
+		if int(selector) >= len(variants) {
+			return 0, ErrUnknownSelector
+		}
+		if variants[selector] == nil {
+			if uint64(len(input)) > startOffset+1 {
+				return 0, errors.New("none union variant cannot carry a value")
+			}
+			val.Set(reflect.ValueOf(Union{Selector: selector}))
+			return startOffset + 1, nil
+		}
+		typ := variants[selector]
+		value := reflect.New(typ).Elem()
+		if typ.Kind() == reflect.Ptr {
+			instantiateConcreteTypeForElement(value, typ.Elem())
+		}
+		end, err := variantUtils[selector].unmarshaler(input[startOffset+1:], value, 0)
+		if err != nil {
+			return 0, withPath(err, "", 1)
+		}
+		val.Set(reflect.ValueOf(Union{Selector: selector, Value: value.Interface()}))
+		return startOffset + 1 + end, nil
+	}
+	hasher := func(val reflect.Value, maxCapacity uint64, c *hashContext) ([32]byte, error) {
+		u := val.Interface().(Union)
+		value, utils, err := unionValue(u)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		var root [32]byte
+		if utils != nil {
+			if root, err = utils.hasher(value, 0, c); err != nil {
+				return [32]byte{}, err
+			}
+		}
+		// The selector is mixed into the root of the value like the length of a list.
+		return c.mixIn(root, uint64(u.Selector)), nil
+	}
+	return &sszUtils{marshaler: marshaler, unmarshaler: unmarshaler, hasher: hasher, typ: unionType}, nil
+}
+
+// determineUnionSize returns the size of the selector and encoded value of a union.
+func determineUnionSize(val reflect.Value) uint64 {
+	u := val.Interface().(Union)
+	if u.Value == nil {
+		return 1
+	}
+	return 1 + determineSize(reflect.ValueOf(u.Value))
+}
@@ -0,0 +1,146 @@
+package ssz_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type unionCheckpoint struct {
+	Epoch uint64
+	Root  [32]byte
+}
+
+type unionContainer struct {
+	Payload ssz.Union `ssz-union:"None,uint64,unionCheckpoint"`
+}
+
+func init() {
+	ssz.RegisterUnionType("unionCheckpoint", unionCheckpoint{})
+}
+
+func TestUnion_MarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		val  unionContainer
+		want []byte
+	}{
+		{
+			name: "None",
+			val:  unionContainer{Payload: ssz.Union{Selector: 0}},
+			want: []byte{4, 0, 0, 0, 0},
+		},
+		{
+			name: "Basic",
+			val:  unionContainer{Payload: ssz.Union{Selector: 1, Value: uint64(5)}},
+			want: []byte{4, 0, 0, 0, 1, 5, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "Container",
+			val:  unionContainer{Payload: ssz.Union{Selector: 2, Value: unionCheckpoint{Epoch: 3, Root: [32]byte{1}}}},
+			want: append([]byte{4, 0, 0, 0, 2, 3, 0, 0, 0, 0, 0, 0, 0, 1}, make([]byte, 31)...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := ssz.Marshal(tt.val)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(encoded, tt.want) {
+				t.Errorf("Expected %v, received %v", tt.want, encoded)
+			}
+			var decoded unionContainer
+			if err := ssz.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.val, decoded) {
+				t.Errorf("Expected %v, received %v", tt.val, decoded)
+			}
+		})
+	}
+}
+
+func TestUnion_UnmarshalRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "UnknownSelector", input: []byte{4, 0, 0, 0, 3, 5, 0, 0, 0, 0, 0, 0, 0}},
+		{name: "NoneWithData", input: []byte{4, 0, 0, 0, 0, 5}},
+		{name: "MissingSelector", input: []byte{4, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded unionContainer
+			if err := ssz.Unmarshal(tt.input, &decoded); err == nil {
+				t.Errorf("Expected error unmarshaling %v, received nil", tt.input)
+			}
+		})
+	}
+}
+
+func TestUnion_MarshalRejectsInvalidValue(t *testing.T) {
+	tests := []struct {
+		name string
+		val  ssz.Union
+	}{
+		{name: "UnknownSelector", val: ssz.Union{Selector: 3, Value: uint64(5)}},
+		{name: "NoneWithValue", val: ssz.Union{Selector: 0, Value: uint64(5)}},
+		{name: "WrongType", val: ssz.Union{Selector: 1, Value: uint32(5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ssz.Marshal(unionContainer{Payload: tt.val}); err == nil {
+				t.Error("Expected error marshaling invalid union, received nil")
+			}
+			if _, err := ssz.HashTreeRoot(unionContainer{Payload: tt.val}); err == nil {
+				t.Error("Expected error hashing invalid union, received nil")
+			}
+		})
+	}
+}
+
+func TestUnion_HashTreeRoot(t *testing.T) {
+	valueRoot := make([]byte, 32)
+	valueRoot[0] = 5
+	selector := make([]byte, 32)
+	selector[0] = 1
+	want := sha256.Sum256(append(valueRoot, selector...))
+
+	// A container with a single field has the root of that field.
+	root, err := ssz.HashTreeRoot(unionContainer{Payload: ssz.Union{Selector: 1, Value: uint64(5)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("Expected %#x, received %#x", want, root)
+	}
+	// The None variant mixes the selector into a zero root.
+	want = sha256.Sum256(make([]byte, 64))
+	root, err = ssz.HashTreeRoot(unionContainer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != want {
+		t.Errorf("Expected %#x, received %#x", want, root)
+	}
+}
+
+func TestUnion_InvalidTag(t *testing.T) {
+	type noneNotFirst struct {
+		Payload ssz.Union `ssz-union:"uint64,None"`
+	}
+	if _, err := ssz.Marshal(noneNotFirst{}); err == nil {
+		t.Error("Expected error for None variant which is not first, received nil")
+	}
+	type unregistered struct {
+		Payload ssz.Union `ssz-union:"None,unknownType"`
+	}
+	if _, err := ssz.Marshal(unregistered{}); err == nil {
+		t.Error("Expected error for unregistered variant type, received nil")
+	}
+}
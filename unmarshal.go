@@ -5,8 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync/atomic"
 )
 
+// strictUnmarshal is 1 while strict unmarshaling is enabled. It is read by every call
+// to Unmarshal, so it is accessed atomically.
+var strictUnmarshal int32 = 1
+
+// ToggleStrictUnmarshal allows to programmatically enable/disable the validation of
+// encodings by Unmarshal. Strict unmarshaling is enabled by default, and rejects any
+// encoding which the specification considers invalid, such as offsets which are out
+// of range or out of order and trailing bytes, instead of decoding it into garbage.
+func ToggleStrictUnmarshal(enableStrictUnmarshal bool) {
+	var enabled int32
+	if enableStrictUnmarshal {
+		enabled = 1
+	}
+	atomic.StoreInt32(&strictUnmarshal, enabled)
+}
+
 // Unmarshal SSZ encoded data and output it into the object pointed by pointer val.
 // Given a struct with the following fields, and some encoded bytes of type []byte,
 // one can then unmarshal the bytes into a pointer of the struct as follows:
@@ -36,8 +53,13 @@ func Unmarshal(input []byte, val interface{}) error {
 	if err != nil {
 		return fmt.Errorf("could not initialize unmarshaler for type: %v, %v", rval.Elem().Type(), err)
 	}
+	if atomic.LoadInt32(&strictUnmarshal) == 1 {
+		if err := validateEncoding(input, rval.Elem().Type()); err != nil {
+			return newDecodeError(err, rval.Elem().Type())
+		}
+	}
 	if _, err = sszUtils.unmarshaler(input, rval.Elem(), 0); err != nil {
-		return fmt.Errorf("could not unmarshal input into type: %v, %v", rval.Elem().Type(), err)
+		return newDecodeError(err, rval.Elem().Type())
 	}
 	return nil
 }
@@ -83,23 +105,32 @@ func makeUnmarshaler(typ reflect.Type) (dec unmarshaler, err error) {
 }
 
 func unmarshalBool(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
+	if err := checkInputSize(input, startOffset, 1); err != nil {
+		return 0, err
+	}
 	v := uint8(input[startOffset])
 	if v == 0 {
 		val.SetBool(false)
 	} else if v == 1 {
 		val.SetBool(true)
 	} else {
-		return 0, fmt.Errorf("expected 0 or 1 but received %d", v)
+		return 0, fmt.Errorf("received %d: %w", v, ErrInvalidBool)
 	}
 	return startOffset + 1, nil
 }
 
 func unmarshalUint8(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
+	if err := checkInputSize(input, startOffset, 1); err != nil {
+		return 0, err
+	}
 	val.SetUint(uint64(input[startOffset]))
 	return startOffset + 1, nil
 }
 
 func unmarshalUint16(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
+	if err := checkInputSize(input, startOffset, 2); err != nil {
+		return 0, err
+	}
 	offset := startOffset + 2
 	buf := make([]byte, 2)
 	copy(buf, input[startOffset:offset])
@@ -108,6 +139,9 @@ func unmarshalUint16(input []byte, val reflect.Value, startOffset uint64) (uint6
 }
 
 func unmarshalUint32(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
+	if err := checkInputSize(input, startOffset, 4); err != nil {
+		return 0, err
+	}
 	offset := startOffset + 4
 	buf := make([]byte, 4)
 	copy(buf, input[startOffset:offset])
@@ -116,6 +150,9 @@ func unmarshalUint32(input []byte, val reflect.Value, startOffset uint64) (uint6
 }
 
 func unmarshalUint64(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
+	if err := checkInputSize(input, startOffset, 8); err != nil {
+		return 0, err
+	}
 	offset := startOffset + 8
 	buf := make([]byte, 8)
 	copy(buf, input[startOffset:offset])
@@ -125,7 +162,10 @@ func unmarshalUint64(input []byte, val reflect.Value, startOffset uint64) (uint6
 
 func makeByteSliceUnmarshaler() (unmarshaler, error) {
 	unmarshaler := func(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
-		offset := startOffset + uint64(len(input))
+		if err := checkInputSize(input, startOffset, 0); err != nil {
+			return 0, err
+		}
+		offset := uint64(len(input))
 		val.SetBytes(input[startOffset:offset])
 		return offset, nil
 	}
@@ -169,10 +209,13 @@ func makeBasicSliceUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 		index := startOffset
 		index, err = elemSSZUtils.unmarshaler(input, val.Index(0), index)
 		if err != nil {
-			return 0, fmt.Errorf("failed to unmarshal element of slice: %v", err)
+			return 0, withPath(err, indexSegment(0), 0)
 		}
 
 		elementSize := index - startOffset
+		if elementSize == 0 {
+			return 0, fmt.Errorf("cannot unmarshal list of empty elements of type %v", typ.Elem())
+		}
 		endOffset := uint64(len(input)) / elementSize
 		if val.Type() != typ {
 			sizes := []uint64{endOffset}
@@ -198,9 +241,10 @@ func makeBasicSliceUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 			if val.Type() == typ {
 				growConcreteSliceType(val, val.Type(), int(i)+1)
 			}
+			elemIndex := index
 			index, err = elemSSZUtils.unmarshaler(input, val.Index(int(i)), index)
 			if err != nil {
-				return 0, fmt.Errorf("failed to unmarshal element of slice: %v", err)
+				return 0, withPath(err, indexSegment(int(i)), elemIndex-startOffset)
 			}
 			i++
 		}
@@ -221,34 +265,18 @@ func makeCompositeSliceUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 			val.Set(newVal)
 			return 0, nil
 		}
-		growConcreteSliceType(val, typ, 1)
-		endOffset := uint64(len(input))
-
-		currentIndex := startOffset
-		nextIndex := currentIndex
-		offsetVal := input[startOffset : startOffset+BytesPerLengthOffset]
-		firstOffset := startOffset + uint64(binary.LittleEndian.Uint32(offsetVal))
-		currentOffset := firstOffset
-		nextOffset := currentOffset
-		i := 0
-		for currentIndex < firstOffset {
-			nextIndex = currentIndex + BytesPerLengthOffset
-			if nextIndex == firstOffset {
-				nextOffset = endOffset
-			} else {
-				nextOffsetVal := input[nextIndex : nextIndex+BytesPerLengthOffset]
-				nextOffset = startOffset + uint64(binary.LittleEndian.Uint32(nextOffsetVal))
-			}
+		offsets, err := readElementOffsets(input, startOffset)
+		if err != nil {
+			return 0, err
+		}
+		for i := 0; i < len(offsets)-1; i++ {
 			// We grow the slice's size to accommodate a new element being unmarshaled.
 			growConcreteSliceType(val, typ, i+1)
-			if _, err := elemSSZUtils.unmarshaler(input[currentOffset:nextOffset], val.Index(i), 0); err != nil {
-				return 0, fmt.Errorf("failed to unmarshal element of slice: %v", err)
+			if _, err := elemSSZUtils.unmarshaler(input[offsets[i]:offsets[i+1]], val.Index(i), 0); err != nil {
+				return 0, withPath(err, indexSegment(i), offsets[i]-startOffset)
 			}
-			i++
-			currentIndex = nextIndex
-			currentOffset = nextOffset
 		}
-		return currentIndex, nil
+		return offsets[0], nil
 	}
 	return unmarshaler, nil
 }
@@ -267,9 +295,10 @@ func makeBasicArrayUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 			if val.Index(i).Kind() == reflect.Ptr {
 				instantiateConcreteTypeForElement(val.Index(i), typ.Elem().Elem())
 			}
+			elemIndex := index
 			index, err = elemSSZUtils.unmarshaler(input, val.Index(i), index)
 			if err != nil {
-				return 0, fmt.Errorf("failed to unmarshal element of array: %v", err)
+				return 0, withPath(err, indexSegment(i), elemIndex-startOffset)
 			}
 			i++
 		}
@@ -285,38 +314,50 @@ func makeCompositeArrayUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 		return nil, err
 	}
 	unmarshaler := func(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
-		currentIndex := startOffset
-		nextIndex := currentIndex
-		offsetVal := input[startOffset : startOffset+BytesPerLengthOffset]
-		firstOffset := startOffset + uint64(binary.LittleEndian.Uint32(offsetVal))
-		currentOffset := firstOffset
-		nextOffset := currentOffset
-		endOffset := uint64(len(input))
-
-		i := 0
-		for currentIndex < firstOffset {
-			nextIndex = currentIndex + BytesPerLengthOffset
-			if nextIndex == firstOffset {
-				nextOffset = endOffset
-			} else {
-				nextOffsetVal := input[nextIndex : nextIndex+BytesPerLengthOffset]
-				nextOffset = startOffset + uint64(binary.LittleEndian.Uint32(nextOffsetVal))
-			}
+		offsets, err := readElementOffsets(input, startOffset)
+		if err != nil {
+			return 0, err
+		}
+		if len(offsets)-1 != val.Len() {
+			return 0, fmt.Errorf("expected %d elements, received %d: %w", val.Len(), len(offsets)-1, ErrVectorLength)
+		}
+		for i := 0; i < len(offsets)-1; i++ {
 			if val.Index(i).Kind() == reflect.Ptr {
 				instantiateConcreteTypeForElement(val.Index(i), typ.Elem().Elem())
 			}
-			if _, err := elemSSZUtils.unmarshaler(input[currentOffset:nextOffset], val.Index(i), 0); err != nil {
-				return 0, fmt.Errorf("failed to unmarshal element of slice: %v", err)
+			if _, err := elemSSZUtils.unmarshaler(input[offsets[i]:offsets[i+1]], val.Index(i), 0); err != nil {
+				return 0, withPath(err, indexSegment(i), offsets[i]-startOffset)
 			}
-			i++
-			currentIndex = nextIndex
-			currentOffset = nextOffset
 		}
-		return currentIndex, nil
+		return offsets[0], nil
 	}
 	return unmarshaler, nil
 }
 
+// readElementOffsets reads the offsets of the elements of a list of variable-size
+// elements encoded at startOffset in input. It returns the start of every element in
+// input, followed by the end of the last one.
+func readElementOffsets(input []byte, startOffset uint64) ([]uint64, error) {
+	if err := checkInputSize(input, startOffset, BytesPerLengthOffset); err != nil {
+		return nil, err
+	}
+	endOffset := uint64(len(input))
+	firstOffset := ReadOffset(input[startOffset:])
+	if firstOffset == 0 || firstOffset%BytesPerLengthOffset != 0 || startOffset+firstOffset > endOffset {
+		return nil, fmt.Errorf("first offset %d: %w", firstOffset, ErrInvalidOffset)
+	}
+	count := firstOffset / BytesPerLengthOffset
+	offsets := make([]uint64, count+1)
+	for i := uint64(0); i < count; i++ {
+		offsets[i] = startOffset + ReadOffset(input[startOffset+i*BytesPerLengthOffset:])
+		if (i > 0 && offsets[i] < offsets[i-1]) || offsets[i] > endOffset {
+			return nil, fmt.Errorf("offset %d of element %d: %w", offsets[i]-startOffset, i, ErrInvalidOffset)
+		}
+	}
+	offsets[count] = endOffset
+	return offsets, nil
+}
+
 func makeStructUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 	fields, err := structFields(typ)
 	if err != nil {
@@ -330,21 +371,21 @@ func makeStructUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 
 		for i := 0; i < len(fixedSizes); i++ {
 			if !isVariableSizeType(fields[i].typ) {
-				if val.Field(i).Kind() == reflect.Ptr {
-					instantiateConcreteTypeForElement(val.Field(i), fields[i].typ.Elem())
+				if val.Field(fields[i].index).Kind() == reflect.Ptr && fields[i].typ.Kind() == reflect.Ptr {
+					instantiateConcreteTypeForElement(val.Field(fields[i].index), fields[i].typ.Elem())
 				}
-				concreteVal := val.Field(i)
-				sszSizeTags, hasTags, err := parseSSZFieldTags(typ.Field(i))
+				concreteVal := val.Field(fields[i].index)
+				sszSizeTags, hasTags, err := parseSSZFieldTags(typ.Field(fields[i].index))
 				if err != nil {
 					return 0, err
 				}
 				if hasTags {
-					concreteType := inferFieldTypeFromSizeTags(typ.Field(i), sszSizeTags)
+					concreteType := inferFieldTypeFromSizeTags(typ.Field(fields[i].index), sszSizeTags)
 					concreteVal = reflect.New(concreteType).Elem()
 					// If the item is a slice, we grow it accordingly based on the size tags.
-					if val.Field(i).Kind() == reflect.Slice {
-						result := growSliceFromSizeTags(val.Field(i), sszSizeTags)
-						val.Field(i).Set(result)
+					if val.Field(fields[i].index).Kind() == reflect.Slice {
+						result := growSliceFromSizeTags(val.Field(fields[i].index), sszSizeTags)
+						val.Field(fields[i].index).Set(result)
 					}
 				}
 				fixedSz := determineFixedSize(concreteVal, fields[i].typ)
@@ -362,31 +403,47 @@ func makeStructUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 			if item > 0 {
 				offsetIndexCounter += item
 			} else {
-				offsetVal := input[offsetIndexCounter : offsetIndexCounter+BytesPerLengthOffset]
-				offsets = append(offsets, startOffset+uint64(binary.LittleEndian.Uint32(offsetVal)))
+				if err := checkInputSize(input, offsetIndexCounter, BytesPerLengthOffset); err != nil {
+					return 0, err
+				}
+				offsets = append(offsets, startOffset+ReadOffset(input[offsetIndexCounter:]))
 				offsetIndexCounter += BytesPerLengthOffset
 			}
 		}
+		if offsetIndexCounter > endOffset {
+			return 0, fmt.Errorf("expected at least %d bytes for type %v, received %d: %w", offsetIndexCounter-startOffset, typ, endOffset-startOffset, ErrIncorrectSize)
+		}
+		// Offsets must point past the fixed part, within the input, and never decrease,
+		// so that every variable-size field can be sliced out of the input.
+		for i, offset := range offsets {
+			if offset < offsetIndexCounter || offset > endOffset || (i > 0 && offset < offsets[i-1]) {
+				return 0, fmt.Errorf("offset %d of variable-size field %d: %w", offset-startOffset, i, ErrInvalidOffset)
+			}
+		}
 		offsets = append(offsets, endOffset)
 		offsetIndex := uint64(0)
 		for i := 0; i < len(fields); i++ {
 			f := fields[i]
 			fieldSize := fixedSizes[i]
-			if val.Field(i).Kind() == reflect.Ptr {
-				instantiateConcreteTypeForElement(val.Field(i), fields[i].typ.Elem())
+			// Pointers encoded as another type, such as big integers, allocate their own values.
+			if val.Field(fields[i].index).Kind() == reflect.Ptr && fields[i].typ.Kind() == reflect.Ptr {
+				instantiateConcreteTypeForElement(val.Field(fields[i].index), fields[i].typ.Elem())
 			}
 			if fieldSize > 0 {
 				nextIndex = currentIndex + fieldSize
-				if _, err := f.sszUtils.unmarshaler(input[currentIndex:nextIndex], val.Field(i), 0); err != nil {
-					return 0, err
+				if _, err := f.sszUtils.unmarshaler(input[currentIndex:nextIndex], val.Field(fields[i].index), 0); err != nil {
+					return 0, withPath(err, fieldSegment(f.name), currentIndex-startOffset)
 				}
 				currentIndex = nextIndex
 
 			} else {
 				firstOff := offsets[offsetIndex]
 				nextOff := offsets[offsetIndex+1]
-				if _, err := f.sszUtils.unmarshaler(input[firstOff:nextOff], val.Field(i), 0); err != nil {
-					return 0, err
+				if err := checkEncodedCapacity(input[firstOff:nextOff], f); err != nil {
+					return 0, withPath(err, fieldSegment(f.name), firstOff-startOffset)
+				}
+				if _, err := f.sszUtils.unmarshaler(input[firstOff:nextOff], val.Field(fields[i].index), 0); err != nil {
+					return 0, withPath(err, fieldSegment(f.name), firstOff-startOffset)
 				}
 				offsetIndex++
 				currentIndex += BytesPerLengthOffset
@@ -397,6 +454,14 @@ func makeStructUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 	return unmarshaler, nil
 }
 
+// checkInputSize checks that input holds at least size bytes from startOffset on.
+func checkInputSize(input []byte, startOffset uint64, size uint64) error {
+	if uint64(len(input)) < startOffset || uint64(len(input))-startOffset < size {
+		return fmt.Errorf("need %d bytes at byte %d of %d: %w", size, startOffset, len(input), ErrIncorrectSize)
+	}
+	return nil
+}
+
 func makePtrUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 	elemType := typ.Elem()
 	elemSSZUtils, err := cachedSSZUtilsNoAcquireLock(elemType)
@@ -404,11 +469,7 @@ func makePtrUnmarshaler(typ reflect.Type) (unmarshaler, error) {
 		return nil, err
 	}
 	unmarshaler := func(input []byte, val reflect.Value, startOffset uint64) (uint64, error) {
-		elemSize, err := elemSSZUtils.unmarshaler(input, val.Elem(), startOffset)
-		if err != nil {
-			return 0, fmt.Errorf("failed to unmarshal to object pointed by pointer: %v", err)
-		}
-		return elemSize, nil
+		return elemSSZUtils.unmarshaler(input, val.Elem(), startOffset)
 	}
 	return unmarshaler, nil
 }
@@ -0,0 +1,142 @@
+package ssz
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// validateEncoding checks that input is exactly the encoding of a value of type typ,
+// as required by the specification: values of fixed size take up exactly their size,
+// offsets point within the input and never decrease, the first offset of a container
+// or list ends its fixed part, and no bytes are left over. Unmarshalers can then
+// trust their input. Types which unmarshal themselves are only checked for size.
+func validateEncoding(input []byte, typ reflect.Type) error {
+	size := uint64(len(input))
+	kind := typ.Kind()
+	switch {
+	case !isVariableSizeType(typ):
+		if expected := fixedTypeSize(typ); size != expected {
+			return fmt.Errorf("expected %d bytes for type %v, received %d: %w", expected, typ, size, ErrIncorrectSize)
+		}
+		return nil
+	case implements(typ, unmarshalerType):
+		return nil
+	case typ == unionType:
+		// Unions are only validated as struct fields, whose tags declare their variants.
+		return nil
+	case typ == bitlistType:
+		return ValidateBitlist(input, math.MaxUint64)
+	case kind == reflect.Ptr:
+		return validateEncoding(input, typ.Elem())
+	case kind == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		return nil
+	case (kind == reflect.Slice || kind == reflect.Array) && !isVariableSizeType(typ.Elem()):
+		// Only slices of fixed-size elements are variable-size here.
+		elemSize := fixedTypeSize(typ.Elem())
+		if elemSize == 0 || size%elemSize != 0 {
+			return fmt.Errorf("%d bytes is not a multiple of the size %d of elements of %v: %w", size, elemSize, typ, ErrIncorrectSize)
+		}
+		return nil
+	case kind == reflect.Slice || kind == reflect.Array:
+		items, err := SplitOffsets(input, math.MaxUint64)
+		if err != nil {
+			return fmt.Errorf("invalid offsets in encoding of %v: %w", typ, err)
+		}
+		if kind == reflect.Array && len(items) != typ.Len() {
+			return fmt.Errorf("expected %d elements of %v, received %d: %w", typ.Len(), typ, len(items), ErrIncorrectSize)
+		}
+		for i, item := range items {
+			if err := validateEncoding(item, typ.Elem()); err != nil {
+				return withPath(err, indexSegment(i), ReadOffset(input[uint64(i)*BytesPerLengthOffset:]))
+			}
+		}
+		return nil
+	case kind == reflect.Struct:
+		return validateStructEncoding(input, typ)
+	default:
+		return fmt.Errorf("type %v is not deserializable", typ)
+	}
+}
+
+func validateStructEncoding(input []byte, typ reflect.Type) error {
+	fields, err := cachedStructFields(typ)
+	if err != nil {
+		return err
+	}
+	size := uint64(len(input))
+	fixedLength := uint64(0)
+	var offsetIndices []uint64
+	var variableFields []field
+	for _, f := range fields {
+		if isVariableSizeType(f.typ) {
+			offsetIndices = append(offsetIndices, fixedLength)
+			variableFields = append(variableFields, f)
+			fixedLength += BytesPerLengthOffset
+		} else {
+			fixedLength += fixedTypeSize(f.typ)
+		}
+	}
+	if size < fixedLength {
+		return fmt.Errorf("expected at least %d bytes for type %v, received %d: %w", fixedLength, typ, size, ErrIncorrectSize)
+	}
+	if len(variableFields) == 0 && size != fixedLength {
+		return fmt.Errorf("expected %d bytes for type %v, received %d: %w", fixedLength, typ, size, ErrIncorrectSize)
+	}
+	offsets := make([]uint64, len(variableFields), len(variableFields)+1)
+	for i, f := range variableFields {
+		offsets[i] = ReadOffset(input[offsetIndices[i]:])
+		switch {
+		case i == 0 && offsets[i] != fixedLength:
+			err = fmt.Errorf("first offset %d does not end the fixed part of %d bytes: %w", offsets[i], fixedLength, ErrInvalidOffset)
+		case i > 0 && offsets[i] < offsets[i-1]:
+			err = fmt.Errorf("offset %d is before the previous offset %d: %w", offsets[i], offsets[i-1], ErrInvalidOffset)
+		case offsets[i] > size:
+			err = fmt.Errorf("offset %d is past the end of %d bytes: %w", offsets[i], size, ErrInvalidOffset)
+		}
+		if err != nil {
+			return withPath(err, fieldSegment(f.name), offsetIndices[i])
+		}
+	}
+	offsets = append(offsets, size)
+	for i, f := range variableFields {
+		start, end := offsets[i], offsets[i+1]
+		if err := checkEncodedCapacity(input[start:end], f); err != nil {
+			return withPath(err, fieldSegment(f.name), start)
+		}
+		if err := validateFieldEncoding(input[start:end], typ, f); err != nil {
+			return withPath(err, fieldSegment(f.name), start)
+		}
+	}
+	return nil
+}
+
+// validateFieldEncoding validates a variable-size struct field, whose tags may
+// change how it is encoded.
+func validateFieldEncoding(input []byte, typ reflect.Type, f field) error {
+	tag, exists := typ.Field(f.index).Tag.Lookup("ssz-union")
+	if f.typ != unionType || !exists {
+		return validateEncoding(input, f.typ)
+	}
+	variants, err := parseUnionVariants(tag)
+	if err != nil {
+		return err
+	}
+	if len(input) == 0 {
+		return fmt.Errorf("union is missing its selector: %w", ErrIncorrectSize)
+	}
+	selector := input[0]
+	if int(selector) >= len(variants) {
+		return ErrUnknownSelector
+	}
+	if variants[selector] == nil {
+		if len(input) > 1 {
+			return fmt.Errorf("none union variant cannot carry a value: %w", ErrIncorrectSize)
+		}
+		return nil
+	}
+	if err := validateEncoding(input[1:], variants[selector]); err != nil {
+		return withPath(err, "", 1)
+	}
+	return nil
+}
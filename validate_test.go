@@ -0,0 +1,136 @@
+package ssz_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+func TestUnmarshal_RejectsInvalidEncodings(t *testing.T) {
+	varEncoded, err := ssz.Marshal(varItemExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forksEncoded, err := ssz.Marshal([]nestedVarItem{nestedVarItemExample, nestedVarItemExample})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		input  []byte
+		target interface{}
+	}{
+		{name: "ShortBasic", input: []byte{1, 2}, target: new(uint64)},
+		{name: "TrailingBytes", input: append(make([]byte, 16), 1), target: new(fork)},
+		{name: "ShortContainer", input: make([]byte, 15), target: new(fork)},
+		{name: "PartialElement", input: make([]byte, 12), target: new([]uint64)},
+		{name: "TruncatedOffsets", input: varEncoded[:5], target: new(varItem)},
+		{name: "FirstOffsetPastFixedPart", input: append([]byte{9}, varEncoded[1:]...), target: new(varItem)},
+		{name: "FirstOffsetInFixedPart", input: append([]byte{4}, varEncoded[1:]...), target: new(varItem)},
+		{name: "DecreasingOffsets", input: append([]byte{8, 0, 0, 0, 7}, varEncoded[5:]...), target: new(varItem)},
+		{name: "OffsetPastEnd", input: append([]byte{8, 0, 0, 0, 255}, varEncoded[5:]...), target: new(varItem)},
+		{name: "MisalignedListOffset", input: append([]byte{7}, forksEncoded[1:]...), target: new([]nestedVarItem)},
+		{name: "ListOffsetPastEnd", input: []byte{8, 0, 0, 0, 255, 0, 0, 0}, target: new([]nestedVarItem)},
+		{name: "WrongVectorLength", input: []byte{4, 0, 0, 0}, target: new([3][]uint64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ssz.Unmarshal(tt.input, tt.target); err == nil {
+				t.Errorf("Expected error unmarshaling %v, received nil", tt.input)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_InvalidUnionAndBitlist(t *testing.T) {
+	item := taggedItem{Root: make([]byte, 32)}
+	encoded, err := ssz.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The payload offset follows the offset of the roots and the two 32 byte fields.
+	payloadStart := binary.LittleEndian.Uint32(encoded[68:])
+	unknownSelector := append([]byte{}, encoded...)
+	unknownSelector[payloadStart] = 3
+	var decoded taggedItem
+	if err := ssz.Unmarshal(unknownSelector, &decoded); err == nil {
+		t.Error("Expected error unmarshaling unknown union selector, received nil")
+	}
+	var bits proofItem
+	encoded, err = ssz.Marshal(proofItem{Bits: []byte{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The bitlist offset follows the slot and three other offsets.
+	encoded[binary.LittleEndian.Uint32(encoded[20:])] = 0
+	if err := ssz.Unmarshal(encoded, &bits); err == nil {
+		t.Error("Expected error unmarshaling bitlist without length bit, received nil")
+	}
+}
+
+func TestUnmarshal_NotStrictRejectsMalformedInputs(t *testing.T) {
+	defer ssz.ToggleStrictUnmarshal(true)
+	ssz.ToggleStrictUnmarshal(false)
+	varEncoded, err := ssz.Marshal(varItemExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without validation, these inputs used to make the unmarshalers read out of range.
+	tests := []struct {
+		name   string
+		input  []byte
+		target interface{}
+	}{
+		{name: "ShortBasic", input: []byte{1, 2}, target: new(uint64)},
+		{name: "ShortBool", input: []byte{}, target: new(bool)},
+		{name: "ShortContainer", input: make([]byte, 15), target: new(fork)},
+		{name: "TruncatedOffsets", input: varEncoded[:5], target: new(varItem)},
+		{name: "DecreasingOffsets", input: append([]byte{8, 0, 0, 0, 7}, varEncoded[5:]...), target: new(varItem)},
+		{name: "OffsetPastEnd", input: append([]byte{8, 0, 0, 0, 255}, varEncoded[5:]...), target: new(varItem)},
+		{name: "ZeroListOffset", input: []byte{0, 0, 0, 0}, target: new([]nestedVarItem)},
+		{name: "MisalignedListOffset", input: []byte{7, 0, 0, 0, 0, 0, 0, 0}, target: new([]nestedVarItem)},
+		{name: "ListOffsetPastEnd", input: []byte{8, 0, 0, 0, 255, 0, 0, 0}, target: new([]nestedVarItem)},
+		{name: "TooManyVectorElements", input: []byte{8, 0, 0, 0, 8, 0, 0, 0}, target: new([1][]uint64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ssz.Unmarshal(tt.input, tt.target); err == nil {
+				t.Errorf("Expected error unmarshaling %v, received nil", tt.input)
+			}
+		})
+	}
+}
+
+func TestToggleStrictUnmarshal(t *testing.T) {
+	defer ssz.ToggleStrictUnmarshal(true)
+	input := append(make([]byte, 16), 1)
+	ssz.ToggleStrictUnmarshal(false)
+	var decoded fork
+	if err := ssz.Unmarshal(input, &decoded); err != nil {
+		t.Errorf("Expected trailing bytes to be ignored when not strict, received %v", err)
+	}
+	ssz.ToggleStrictUnmarshal(true)
+	if err := ssz.Unmarshal(input, &decoded); err == nil {
+		t.Error("Expected error unmarshaling trailing bytes, received nil")
+	}
+}
+
+func TestToggleStrictUnmarshal_Concurrently(t *testing.T) {
+	defer ssz.ToggleStrictUnmarshal(true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			ssz.ToggleStrictUnmarshal(i%2 == 0)
+		}
+	}()
+	input := make([]byte, 16)
+	for i := 0; i < 100; i++ {
+		var decoded fork
+		if err := ssz.Unmarshal(input, &decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+}
@@ -0,0 +1,581 @@
+package ssz
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// View holds a value as a persistent Merkle tree, so that its root can be recomputed
+// after setting some of its fields or list elements by hashing only the nodes on the
+// paths from them to the root. Nodes are never modified once hashed, so copying a view
+// is cheap: the copy shares all its nodes with the original until either is changed.
+//  view, err := ssz.NewView(state)
+//  if err != nil {
+//      return fmt.Errorf("failed to create view: %v", err)
+//  }
+//  if err := view.Set(uint64(32000000000), "balances", 12); err != nil {
+//      return fmt.Errorf("failed to set balance: %v", err)
+//  }
+//  root, err := view.HashTreeRoot()
+// Paths are followed as in GeneralizedIndex, and lists must declare their capacity with
+// an ssz-max tag to be indexed into. A View is not safe for concurrent use, but copies
+// can be used concurrently with each other and with the view they were copied from.
+type View struct {
+	typ       reflect.Type
+	utils     *sszUtils
+	hasher    Hasher
+	root      *treeNode
+	zeroNodes []*treeNode
+}
+
+// treeNode is a node of the Merkle tree of a view. Leaves have no children, and their
+// root is a chunk. The root of a branch is computed when it is first needed.
+type treeNode struct {
+	left, right *treeNode
+	root        [32]byte
+	dirty       bool
+}
+
+func leafNode(chunk [32]byte) *treeNode {
+	return &treeNode{root: chunk}
+}
+
+func branchNode(left, right *treeNode) *treeNode {
+	return &treeNode{left: left, right: right, dirty: true}
+}
+
+// hash returns the root of the node, hashing the branches below it which have not
+// been hashed yet. The pair buffer is shared by all the nodes of the tree.
+func (n *treeNode) hash(h Hasher, pair *[64]byte) [32]byte {
+	if n.dirty {
+		left, right := n.left.hash(h, pair), n.right.hash(h, pair)
+		copy(pair[:32], left[:])
+		copy(pair[32:], right[:])
+		n.root = h.Hash(pair[:])
+		n.dirty = false
+	}
+	return n.root
+}
+
+// child returns the node at gindex, relative to n.
+func (n *treeNode) child(gindex uint64) (*treeNode, error) {
+	for depth := bitLength(gindex) - 1; depth > 0; depth-- {
+		if n.left == nil {
+			return nil, errors.New("cannot descend below a leaf of the tree")
+		}
+		if gindex>>(depth-1)&1 == 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return n, nil
+}
+
+// replace returns a copy of n in which the node at gindex, relative to n, is replaced
+// by the result of update. Only the nodes on the path to it are copied.
+func (n *treeNode) replace(gindex uint64, update func(*treeNode) (*treeNode, error)) (*treeNode, error) {
+	if gindex == 1 {
+		return update(n)
+	}
+	if n.left == nil {
+		return nil, errors.New("cannot descend below a leaf of the tree")
+	}
+	depth := bitLength(gindex) - 1
+	// The generalized index of the node within the child of n it descends into.
+	childIndex := gindex&(1<<(depth-1)-1) | 1<<(depth-1)
+	if gindex>>(depth-1)&1 == 0 {
+		left, err := n.left.replace(childIndex, update)
+		if err != nil {
+			return nil, err
+		}
+		return branchNode(left, n.right), nil
+	}
+	right, err := n.right.replace(childIndex, update)
+	if err != nil {
+		return nil, err
+	}
+	return branchNode(n.left, right), nil
+}
+
+// length returns the length mixed into the root of a list.
+func (n *treeNode) length() (uint64, error) {
+	if n.right == nil {
+		return 0, errors.New("list has no length")
+	}
+	return binary.LittleEndian.Uint64(n.right.root[:8]), nil
+}
+
+// NewView returns a view of val, which must be a struct, list or vector, or a pointer
+// to one. The view is hashed with the Hasher set by SetHasher.
+func NewView(val interface{}) (*View, error) {
+	if val == nil {
+		return nil, errors.New("untyped nil is not supported")
+	}
+	rval := reflect.ValueOf(val)
+	for rval.Kind() == reflect.Ptr {
+		if rval.IsNil() {
+			return nil, errors.New("nil pointer is not supported")
+		}
+		rval = rval.Elem()
+	}
+	utils, err := cachedSSZUtils(rval.Type())
+	if err != nil {
+		return nil, fmt.Errorf("could not get ssz utils for type: %v: %v", rval.Type(), err)
+	}
+	h := currentHasher()
+	v := &View{
+		typ:       rval.Type(),
+		utils:     utils,
+		hasher:    h,
+		zeroNodes: zeroNodesOf(h),
+	}
+	if v.root, err = v.build(rval, v.typ, utils, 0); err != nil {
+		return nil, newHashError(err, v.typ)
+	}
+	return v, nil
+}
+
+// HashTreeRoot returns the hash tree root of the value held by the view.
+func (v *View) HashTreeRoot() ([32]byte, error) {
+	var pair [64]byte
+	return v.root.hash(v.hasher, &pair), nil
+}
+
+// Copy returns a view of the same value, which shares its nodes with v.
+func (v *View) Copy() *View {
+	// Hashing the tree first means the nodes shared by the views are never written to.
+	var pair [64]byte
+	v.root.hash(v.hasher, &pair)
+	cp := *v
+	return &cp
+}
+
+// Set replaces the value at the end of path, which must have the same Go type as the
+// field or element found there:
+//  err := view.Set(validator, "validators", 3)
+// An empty path replaces the whole value.
+func (v *View) Set(value interface{}, path ...interface{}) error {
+	p, err := v.resolve(path)
+	if err != nil {
+		return err
+	}
+	update, err := v.updater(value, p)
+	if err != nil {
+		return err
+	}
+	root, err := v.replace(p.steps, update)
+	if err != nil {
+		return newHashError(err, v.typ)
+	}
+	v.root = root
+	return nil
+}
+
+// Append adds value to the end of the list at the end of path, which must declare its
+// capacity with an ssz-max tag.
+func (v *View) Append(value interface{}, path ...interface{}) error {
+	p, err := v.resolve(path)
+	if err != nil {
+		return err
+	}
+	if p.typ.Kind() != reflect.Slice || p.isBitlist {
+		return fmt.Errorf("cannot append to %v", p.goType)
+	}
+	length, err := p.node.length()
+	if err != nil {
+		return err
+	}
+	if p.capacity == 0 {
+		return fmt.Errorf("list of type %v has no ssz-max capacity", p.goType)
+	}
+	if length >= p.capacity {
+		return fmt.Errorf("%d items exceed the ssz-max of %d: %w", length+1, p.capacity, ErrListTooBig)
+	}
+	elem, err := v.element(p, length)
+	if err != nil {
+		return err
+	}
+	update, err := v.updater(value, elem)
+	if err != nil {
+		return err
+	}
+	lengthChunk := [32]byte{}
+	binary.LittleEndian.PutUint64(lengthChunk[:8], length+1)
+	root, err := v.replace(p.steps, func(list *treeNode) (*treeNode, error) {
+		list, err := list.replace(elem.steps[0], update)
+		if err != nil {
+			return nil, err
+		}
+		return branchNode(list.left, leafNode(lengthChunk)), nil
+	})
+	if err != nil {
+		return newHashError(err, v.typ)
+	}
+	v.root = root
+	return nil
+}
+
+// viewPath is the value found at the end of a path through a view.
+type viewPath struct {
+	// steps holds the generalized index of each value on the path, relative to the
+	// value before it.
+	steps    []uint64
+	node     *treeNode
+	goType   reflect.Type
+	typ      reflect.Type
+	utils    *sszUtils
+	capacity uint64
+	// isBitlist is set for bitlist fields, which are hashed with their capacity.
+	isBitlist bool
+	// packed is set for basic values sharing the chunk at node with their neighbours,
+	// in which they start at offset.
+	packed bool
+	offset uint64
+}
+
+// resolve follows path down the tree of the view.
+func (v *View) resolve(path []interface{}) (*viewPath, error) {
+	p := &viewPath{node: v.root, goType: v.typ, typ: v.typ, utils: v.utils}
+	for _, elem := range path {
+		for p.typ.Kind() == reflect.Ptr {
+			utils, err := cachedSSZUtils(p.typ.Elem())
+			if err != nil {
+				return nil, err
+			}
+			p.goType, p.typ, p.utils = p.goType.Elem(), p.typ.Elem(), utils
+		}
+		var next *viewPath
+		var err error
+		switch {
+		case p.packed || p.isBitlist || p.goType == bigIntType || p.typ == unionType ||
+			implements(p.typ, hashRootType) || isBasicType(p.typ.Kind()) || isBasicUintType(p.typ):
+			return nil, fmt.Errorf("cannot descend into type %v with path element %v", p.goType, elem)
+		case p.typ.Kind() == reflect.Struct:
+			name, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected field name to descend into struct %v, received %v", p.typ, elem)
+			}
+			next, err = v.field(p, name)
+		case p.typ.Kind() == reflect.Slice || p.typ.Kind() == reflect.Array:
+			index, ok := pathIndex(elem)
+			if !ok {
+				return nil, fmt.Errorf("expected index to descend into %v, received %v", p.goType, elem)
+			}
+			if p.typ.Kind() == reflect.Slice {
+				length, err := p.node.length()
+				if err != nil {
+					return nil, err
+				}
+				if index >= length {
+					return nil, fmt.Errorf("index %d out of range for list of length %d", index, length)
+				}
+			}
+			next, err = v.element(p, index)
+		default:
+			return nil, fmt.Errorf("type %v is not hashable", p.typ)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if next.node, err = p.node.child(next.steps[0]); err != nil {
+			return nil, fmt.Errorf("cannot descend into %v with path element %v: %v", p.goType, elem, err)
+		}
+		next.steps = append(p.steps, next.steps[0])
+		p = next
+	}
+	return p, nil
+}
+
+// field returns the path to the named field of the struct at p, whose only step is
+// relative to p.
+func (v *View) field(p *viewPath, name string) (*viewPath, error) {
+	fields, err := cachedStructFields(p.typ)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := fieldIndex(fields, name)
+	if !ok {
+		return nil, fmt.Errorf("struct %v has no field %s", p.typ, name)
+	}
+	f := fields[i]
+	gindex, err := concatGeneralizedIndex(1, treeDepth(uint64(len(fields))), uint64(i))
+	if err != nil {
+		return nil, err
+	}
+	return &viewPath{
+		steps:     []uint64{gindex},
+		goType:    p.goType.Field(f.index).Type,
+		typ:       f.typ,
+		utils:     f.sszUtils,
+		capacity:  f.capacity,
+		isBitlist: p.goType.Field(f.index).Type == bitlistType,
+	}, nil
+}
+
+// element returns the path to the element at index of the list or vector at p, whose
+// only step is relative to p.
+func (v *View) element(p *viewPath, index uint64) (*viewPath, error) {
+	gindex, err := elementGeneralizedIndex(1, p.typ, p.capacity, false /* is bitlist */, index)
+	if err != nil {
+		return nil, err
+	}
+	utils, err := cachedSSZUtils(p.typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	elem := &viewPath{
+		steps:  []uint64{gindex},
+		goType: p.goType.Elem(),
+		typ:    p.typ.Elem(),
+		utils:  utils,
+	}
+	if isBasicType(elem.typ.Kind()) || isBasicUintType(elem.typ) {
+		elem.packed = true
+		elem.offset = index * fixedTypeSize(elem.typ) % uint64(BytesPerChunk)
+	}
+	return elem, nil
+}
+
+// updater returns the function replacing the node at p with one holding value.
+func (v *View) updater(value interface{}, p *viewPath) (func(*treeNode) (*treeNode, error), error) {
+	if value == nil || !reflect.TypeOf(value).AssignableTo(p.goType) {
+		return nil, fmt.Errorf("cannot set value of type %T in place of %v", value, p.goType)
+	}
+	val := reflect.ValueOf(value)
+	if p.packed {
+		// Only the bytes of the value are replaced in the chunk it shares.
+		c := newHashContext(v.hasher)
+		defer c.release()
+		if err := c.appendBasic(val, p.utils); err != nil {
+			return nil, err
+		}
+		serialized := append([]byte{}, c.chunks...)
+		return func(n *treeNode) (*treeNode, error) {
+			chunk := n.root
+			copy(chunk[p.offset:], serialized)
+			return leafNode(chunk), nil
+		}, nil
+	}
+	var node *treeNode
+	var err error
+	if p.isBitlist {
+		node, err = v.buildBitlist(val.Interface().(bitfield.Bitlist), p.capacity)
+	} else {
+		node, err = v.build(val, p.typ, p.utils, p.capacity)
+	}
+	if err != nil {
+		return nil, newHashError(err, p.goType)
+	}
+	return func(*treeNode) (*treeNode, error) {
+		return node, nil
+	}, nil
+}
+
+// replace returns the root of the tree in which the node at the end of steps is
+// replaced by the result of update.
+func (v *View) replace(steps []uint64, update func(*treeNode) (*treeNode, error)) (*treeNode, error) {
+	var replaceFrom func(n *treeNode, steps []uint64) (*treeNode, error)
+	replaceFrom = func(n *treeNode, steps []uint64) (*treeNode, error) {
+		if len(steps) == 0 {
+			return update(n)
+		}
+		return n.replace(steps[0], func(child *treeNode) (*treeNode, error) {
+			return replaceFrom(child, steps[1:])
+		})
+	}
+	return replaceFrom(v.root, steps)
+}
+
+// build returns the tree of val, which is hashed as typ using utils. It classifies
+// typ with kindOf, as makeHasher does.
+func (v *View) build(val reflect.Value, typ reflect.Type, utils *sszUtils, capacity uint64) (*treeNode, error) {
+	if typ.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return leafNode([32]byte{}), nil
+		}
+		elemUtils, err := cachedSSZUtils(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return v.build(val.Elem(), typ.Elem(), elemUtils, capacity)
+	}
+	kind, ok := kindOf(typ, false)
+	switch {
+	case implements(typ, hashRootType) || ok && (kind == KindBasic || kind == KindUnion):
+		// Values hashed as a whole are leaves which cannot be descended into.
+		root, err := rootOf(val, utils, capacity, v.hasher)
+		if err != nil {
+			return nil, err
+		}
+		return leafNode(root), nil
+	case !ok:
+		return nil, fmt.Errorf("type %v is not hashable", typ)
+	case kind == KindVector && isPacked(typ.Elem()):
+		leaves, err := v.pack(val, utils, false /* elements */)
+		if err != nil {
+			return nil, err
+		}
+		return v.merkleize(leaves, uint64(len(leaves)))
+	case kind == KindVector:
+		nodes, err := v.buildElements(val, typ)
+		if err != nil {
+			return nil, err
+		}
+		return v.merkleize(nodes, uint64(val.Len()))
+	case kind == KindList && isPacked(typ.Elem()):
+		elemUtils, err := cachedSSZUtils(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		leaves, err := v.pack(val, elemUtils, true /* elements */)
+		if err != nil {
+			return nil, err
+		}
+		return v.listNode(leaves, listLimit(typ, capacity, uint64(val.Len())), uint64(val.Len()))
+	case kind == KindList:
+		nodes, err := v.buildElements(val, typ)
+		if err != nil {
+			return nil, err
+		}
+		return v.listNode(nodes, listLimit(typ, capacity, uint64(val.Len())), uint64(val.Len()))
+	default:
+		return v.buildStruct(val, typ)
+	}
+}
+
+// listLimit returns the number of leaves of the tree which build merkleizes the
+// elements of a list of type typ into, the list holding length of them.
+func listLimit(typ reflect.Type, capacity uint64, length uint64) uint64 {
+	elem := typ.Elem()
+	switch {
+	case isPacked(elem):
+		if limit := (capacity*fixedTypeSize(elem) + 31) / 32; limit > 0 {
+			return limit
+		}
+		return 1
+	case capacity > 0:
+		return capacity
+	case isBasicTypeArray(elem, elem.Kind()):
+		return 1
+	default:
+		return length
+	}
+}
+
+func (v *View) buildStruct(val reflect.Value, typ reflect.Type) (*treeNode, error) {
+	fields, err := cachedStructFields(typ)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*treeNode, len(fields))
+	for i, f := range fields {
+		fieldVal := val.Field(f.index)
+		if kind, _ := kindOf(f.typ, true); kind == KindBitlist {
+			nodes[i], err = v.buildBitlist(fieldVal.Interface().(bitfield.Bitlist), f.capacity)
+		} else {
+			nodes[i], err = v.build(fieldVal, f.typ, f.sszUtils, f.capacity)
+		}
+		if err != nil {
+			return nil, withPath(err, fieldSegment(f.name), 0)
+		}
+	}
+	return v.merkleize(nodes, uint64(len(fields)))
+}
+
+func (v *View) buildElements(val reflect.Value, typ reflect.Type) ([]*treeNode, error) {
+	elemUtils, err := cachedSSZUtils(typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*treeNode, val.Len())
+	for i := range nodes {
+		if nodes[i], err = v.build(val.Index(i), typ.Elem(), elemUtils, 0); err != nil {
+			return nil, withPath(err, indexSegment(i), 0)
+		}
+	}
+	return nodes, nil
+}
+
+func (v *View) buildBitlist(bits bitfield.Bitlist, capacity uint64) (*treeNode, error) {
+	var leaves []*treeNode
+	length := uint64(0)
+	if len(bits) > 0 {
+		for _, chunk := range toChunks(bits.Bytes()) {
+			leaves = append(leaves, leafNode(chunk))
+		}
+		length = bits.Len()
+	}
+	return v.listNode(leaves, (capacity+255)/256, length)
+}
+
+// pack serializes val, or each of its elements, with utils, into leaves.
+func (v *View) pack(val reflect.Value, utils *sszUtils, elements bool) ([]*treeNode, error) {
+	c := newHashContext(v.hasher)
+	defer c.release()
+	if !elements {
+		if err := c.appendBasic(val, utils); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; elements && i < val.Len(); i++ {
+		if err := c.appendBasic(val.Index(i), utils); err != nil {
+			return nil, withPath(err, indexSegment(i), 0)
+		}
+	}
+	chunks := toChunks(c.chunks)
+	leaves := make([]*treeNode, len(chunks))
+	for i, chunk := range chunks {
+		leaves[i] = leafNode(chunk)
+	}
+	return leaves, nil
+}
+
+// listNode merkleizes nodes into a tree of limit leaves, and mixes in length.
+func (v *View) listNode(nodes []*treeNode, limit uint64, length uint64) (*treeNode, error) {
+	data, err := v.merkleize(nodes, limit)
+	if err != nil {
+		return nil, err
+	}
+	lengthChunk := [32]byte{}
+	binary.LittleEndian.PutUint64(lengthChunk[:8], length)
+	return branchNode(data, leafNode(lengthChunk)), nil
+}
+
+// merkleize returns the root node of a tree of limit leaves, the first of which are
+// nodes. The subtrees past the last node are shared zero nodes.
+func (v *View) merkleize(nodes []*treeNode, limit uint64) (*treeNode, error) {
+	count := uint64(len(nodes))
+	if count > limit {
+		return nil, fmt.Errorf("chunk count = %d cannot be greater than padding = %d", count, limit)
+	}
+	depth := treeDepth(limit)
+	if count == 0 {
+		return v.zeroNodes[depth], nil
+	}
+	for level := uint64(0); level < depth; level++ {
+		if len(nodes)%2 == 1 {
+			nodes = append(nodes, v.zeroNodes[level])
+		}
+		for i := 0; i < len(nodes)/2; i++ {
+			nodes[i] = branchNode(nodes[2*i], nodes[2*i+1])
+		}
+		nodes = nodes[:len(nodes)/2]
+	}
+	return nodes[0], nil
+}
+
+// zeroNodesOf returns the roots of trees of zero chunks under h, indexed by depth.
+func zeroNodesOf(h Hasher) []*treeNode {
+	hashes := zeroHashesOf(h)
+	nodes := make([]*treeNode, len(hashes))
+	nodes[0] = leafNode(hashes[0])
+	for i := 1; i < len(nodes); i++ {
+		nodes[i] = &treeNode{left: nodes[i-1], right: nodes[i-1], root: hashes[i]}
+	}
+	return nodes
+}
@@ -0,0 +1,256 @@
+package ssz_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	ssz "github.com/prysmaticlabs/go-ssz"
+)
+
+type viewState struct {
+	Slot          uint64
+	Fork          *testCheckpoint
+	Roots         [8][32]byte
+	Validators    []testValidator  `ssz-max:"1024"`
+	Balances      []uint64         `ssz-max:"1024"`
+	Mixes         [][]byte         `ssz-size:"?,32" ssz-max:"64"`
+	Participation []byte           `ssz-max:"1024"`
+	Bits          bitfield.Bitlist `ssz-max:"64"`
+	Total         *big.Int         `ssz-type:"uint256"`
+	Payload       ssz.Union        `ssz-union:"None,uint64"`
+	Justified     testCheckpoint
+}
+
+func newViewState(n int) *viewState {
+	s := &viewState{
+		Slot:          5,
+		Fork:          &testCheckpoint{Epoch: 1},
+		Validators:    make([]testValidator, n),
+		Balances:      make([]uint64, n),
+		Participation: make([]byte, n),
+		Bits:          bitfield.NewBitlist(10),
+		Total:         big.NewInt(1000),
+		Payload:       ssz.Union{Selector: 1, Value: uint64(7)},
+	}
+	for i := 0; i < n; i++ {
+		s.Validators[i] = newTestValidator(i)
+		s.Balances[i] = uint64(i) * 10
+		s.Participation[i] = byte(i)
+	}
+	s.Roots[3][0] = 3
+	s.Mixes = [][]byte{make([]byte, 32)}
+	s.Bits.SetBitAt(3, true)
+	return s
+}
+
+func checkViewRoot(t *testing.T, view *ssz.View, val interface{}) {
+	t.Helper()
+	got, err := view.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ssz.HashTreeRoot(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("View.HashTreeRoot() = %#x, want %#x", got, want)
+	}
+}
+
+func TestView_Set(t *testing.T) {
+	state := newViewState(37)
+	view, err := ssz.NewView(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkViewRoot(t, view, state)
+	root := [32]byte{9}
+	bits := bitfield.NewBitlist(40)
+	bits.SetBitAt(39, true)
+	tests := []struct {
+		name  string
+		value interface{}
+		path  []interface{}
+		apply func(s *viewState)
+	}{
+		{"slot", uint64(6), []interface{}{"slot"}, func(s *viewState) { s.Slot = 6 }},
+		{"pointer field", uint64(2), []interface{}{"fork", "epoch"}, func(s *viewState) { s.Fork.Epoch = 2 }},
+		{"vector element", root, []interface{}{"roots", 7}, func(s *viewState) { s.Roots[7] = root }},
+		{"byte of vector element", byte(4), []interface{}{"roots", 3, 31}, func(s *viewState) { s.Roots[3][31] = 4 }},
+		{"balance", uint64(123), []interface{}{"balances", 33}, func(s *viewState) { s.Balances[33] = 123 }},
+		{"validator field", true, []interface{}{"validators", 20, "slashed"}, func(s *viewState) { s.Validators[20].Slashed = true }},
+		{"pubkey byte", byte(0xff), []interface{}{"validators", 0, "pubkey", 47}, func(s *viewState) { s.Validators[0].Pubkey[47] = 0xff }},
+		{"validator", testValidator{Pubkey: make([]byte, 48), Balance: 99}, []interface{}{"validators", 36},
+			func(s *viewState) { s.Validators[36] = testValidator{Pubkey: make([]byte, 48), Balance: 99} }},
+		{"participation", byte(8), []interface{}{"participation", 31}, func(s *viewState) { s.Participation[31] = 8 }},
+		{"list element of vectors", []byte{1: 1, 31: 0}, []interface{}{"mixes", 0}, func(s *viewState) { s.Mixes[0] = []byte{1: 1, 31: 0} }},
+		{"whole list", []uint64{1, 2, 3}, []interface{}{"balances"}, func(s *viewState) { s.Balances = []uint64{1, 2, 3} }},
+		{"bitlist", bits, []interface{}{"bits"}, func(s *viewState) { s.Bits = bits }},
+		{"big integer", big.NewInt(5), []interface{}{"total"}, func(s *viewState) { s.Total = big.NewInt(5) }},
+		{"union", ssz.Union{Selector: 0}, []interface{}{"payload"}, func(s *viewState) { s.Payload = ssz.Union{Selector: 0} }},
+		{"nil pointer", (*testCheckpoint)(nil), []interface{}{"fork"}, func(s *viewState) { s.Fork = nil }},
+		{"struct", testCheckpoint{Epoch: 3, Root: root}, []interface{}{"justified"},
+			func(s *viewState) { s.Justified = testCheckpoint{Epoch: 3, Root: root} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := view.Set(tt.value, tt.path...); err != nil {
+				t.Fatal(err)
+			}
+			tt.apply(state)
+			checkViewRoot(t, view, state)
+		})
+	}
+	whole := newViewState(3)
+	if err := view.Set(*whole); err != nil {
+		t.Fatal(err)
+	}
+	checkViewRoot(t, view, whole)
+}
+
+func TestView_Append(t *testing.T) {
+	state := newViewState(0)
+	view, err := ssz.NewView(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 70; i++ {
+		validator := testValidator{Pubkey: make([]byte, 48), Balance: uint64(i)}
+		if err := view.Append(validator, "validators"); err != nil {
+			t.Fatal(err)
+		}
+		if err := view.Append(uint64(i), "balances"); err != nil {
+			t.Fatal(err)
+		}
+		state.Validators = append(state.Validators, validator)
+		state.Balances = append(state.Balances, uint64(i))
+		checkViewRoot(t, view, state)
+	}
+	mixes := make([]interface{}, 63)
+	for i := range mixes {
+		mixes[i] = []byte{31: byte(i)}
+		state.Mixes = append(state.Mixes, []byte{31: byte(i)})
+		if err := view.Append(mixes[i], "mixes"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkViewRoot(t, view, state)
+	if err := view.Append(make([]byte, 32), "mixes"); !errors.Is(err, ssz.ErrListTooBig) {
+		t.Errorf("Expected ErrListTooBig appending past the ssz-max, received %v", err)
+	}
+}
+
+func TestView_Copy(t *testing.T) {
+	state := newViewState(100)
+	view, err := ssz.NewView(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fork := view.Copy()
+	if err := fork.Set(uint64(1), "balances", 50); err != nil {
+		t.Fatal(err)
+	}
+	checkViewRoot(t, view, state)
+	forked := newViewState(100)
+	forked.Balances[50] = 1
+	checkViewRoot(t, fork, forked)
+
+	if err := view.Append(testValidator{Pubkey: make([]byte, 48)}, "validators"); err != nil {
+		t.Fatal(err)
+	}
+	state.Validators = append(state.Validators, testValidator{Pubkey: make([]byte, 48)})
+	checkViewRoot(t, view, state)
+	checkViewRoot(t, fork, forked)
+}
+
+func TestView_CopiesAreIndependent(t *testing.T) {
+	view, err := ssz.NewView(newViewState(256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		go func(fork *ssz.View, i int) {
+			for j := 0; j < 100; j++ {
+				if err := fork.Set(uint64(i*j), "balances", j); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := fork.HashTreeRoot(); err != nil {
+					errs <- err
+					return
+				}
+			}
+			errs <- nil
+		}(view.Copy(), i)
+	}
+	for i := 0; i < 4; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+	checkViewRoot(t, view, newViewState(256))
+}
+
+func TestView_Errors(t *testing.T) {
+	view, err := ssz.NewView(newViewState(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := view.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		value interface{}
+		path  []interface{}
+	}{
+		{"unknown field", uint64(1), []interface{}{"unknown"}},
+		{"index past length", uint64(1), []interface{}{"balances", 4}},
+		{"index into basic value", uint64(1), []interface{}{"slot", 0}},
+		{"index into bitlist", true, []interface{}{"bits", 0}},
+		{"field name into list", uint64(1), []interface{}{"balances", "first"}},
+		{"wrong type", uint32(1), []interface{}{"balances", 0}},
+		{"untyped nil", nil, []interface{}{"fork"}},
+		{"list too long", make([]uint64, 1025), []interface{}{"balances"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := view.Set(tt.value, tt.path...); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+	if err := view.Append(uint64(1), "slot"); err == nil {
+		t.Error("Expected error appending to a basic value")
+	}
+	after, err := view.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after != before {
+		t.Error("Failed updates changed the root of the view")
+	}
+}
+
+func BenchmarkView_SetBalance(b *testing.B) {
+	state := newViewState(1024)
+	view, err := ssz.NewView(state)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := view.Set(uint64(n), "balances", n%1024); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := view.HashTreeRoot(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}